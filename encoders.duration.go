@@ -0,0 +1,150 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the units parseDuration() adds to the ones of time.ParseDuration()
+var longDurationUnits = map[string]float64{
+	"d": 24,
+	"w": 7 * 24,
+}
+
+// parses a duration written for time.ParseDuration() ("1h30m"), with
+// days and weeks as extra units ("7d", "2w3d12h", "1.5d"), or in
+// ISO 8601 ("PT15M", "P1DT2H", "P2W"). A day is always 24 hours, and
+// ISO 8601 years and months, whose length varies, are rejected
+func parseDuration(str string) (time.Duration, error) {
+	str = strings.TrimSpace(str)
+	body := strings.TrimLeft(str, "+-")
+	if strings.HasPrefix(body, "P") || strings.HasPrefix(body, "p") {
+		return parseISODuration(str)
+	}
+	if d, err := time.ParseDuration(str); err == nil {
+		return d, nil
+	}
+	return parseGoDuration(expandLongUnits(str))
+}
+
+func parseGoDuration(str string) (time.Duration, error) {
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, errors.New(ErrCnvFailed)
+	}
+	return d, nil
+}
+
+// rewrites the days and weeks of str in hours, "2w3d12h" is
+// "336h72h12h", which time.ParseDuration() adds up. Segments are
+// a number and a unit, the unit running up to the next number
+func expandLongUnits(str string) string {
+	var out strings.Builder
+	if str != "" && (str[0] == '-' || str[0] == '+') {
+		out.WriteByte(str[0])
+		str = str[1:]
+	}
+	isNum := func(ch byte) bool {
+		return (ch >= '0' && ch <= '9') || ch == '.'
+	}
+	for str != "" {
+		i := 0
+		for i < len(str) && isNum(str[i]) {
+			i++
+		}
+		j := i
+		for j < len(str) && !isNum(str[j]) {
+			j++
+		}
+		number, unit := str[:i], str[i:j]
+		hours, ok := longDurationUnits[unit]
+		n, err := strconv.ParseFloat(number, 64)
+		if ok && err == nil {
+			out.WriteString(strconv.FormatFloat(n*hours, 'f', -1, 64) + "h")
+		} else {
+			out.WriteString(str[:j])
+		}
+		str = str[j:]
+	}
+	return out.String()
+}
+
+// the components of an ISO 8601 duration, in hours,
+// M is read as minutes after the time designator T
+var isoDateUnits = map[byte]float64{'W': 7 * 24, 'D': 24}
+var isoTimeUnits = map[byte]string{'H': "h", 'M': "m", 'S': "s"}
+
+func parseISODuration(str string) (time.Duration, error) {
+	var out strings.Builder
+	if str[0] == '-' || str[0] == '+' {
+		out.WriteByte(str[0])
+		str = str[1:]
+	}
+	str = strings.ToUpper(str[1:])
+	inTime, components, start := false, 0, 0
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		if (ch >= '0' && ch <= '9') || ch == '.' || ch == ',' {
+			continue
+		}
+		if ch == 'T' && !inTime && i == start {
+			inTime = true
+			start = i + 1
+			continue
+		}
+		if i == start {
+			return 0, errors.New(ErrCnvFailed)
+		}
+		n, err := strconv.ParseFloat(strings.Replace(str[start:i], ",", ".", 1), 64)
+		if err != nil || math.IsInf(n, 0) {
+			return 0, errors.New(ErrCnvFailed)
+		}
+		if inTime {
+			unit, ok := isoTimeUnits[ch]
+			if !ok {
+				return 0, errors.New(ErrCnvFailed)
+			}
+			out.WriteString(strconv.FormatFloat(n, 'f', -1, 64) + unit)
+		} else {
+			hours, ok := isoDateUnits[ch]
+			if !ok {
+				return 0, errors.New(ErrCnvFailed)
+			}
+			out.WriteString(strconv.FormatFloat(n*hours, 'f', -1, 64) + "h")
+		}
+		components++
+		start = i + 1
+	}
+	// a T must be followed by at least one time component ("P1DT")
+	if components == 0 || start != len(str) || (inTime && str[len(str)-1] == 'T') {
+		return 0, errors.New(ErrCnvFailed)
+	}
+	return parseGoDuration(out.String())
+}
+
+// converts an integer of any type (or a whole json.Number) to a
+// duration, counting in unit (e.g. time.Second)
+func durationFromInt(val interface{}, unit time.Duration) (time.Duration, error) {
+	n, err := lenientInt(val, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n != 0 && (n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit)) {
+		return 0, errors.New(ErrOverflow)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// parses str with parseDuration(), a bare integer ("30") is read
+// in unit, unless unit is zero
+func parseDurationIn(str string, unit time.Duration) (time.Duration, error) {
+	if unit != 0 {
+		if n, err := parseIntLiteral(strings.TrimSpace(str), 64); err == nil {
+			return durationFromInt(n, unit)
+		}
+	}
+	return parseDuration(str)
+}
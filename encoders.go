@@ -1,8 +1,8 @@
 package dyanmic_params
 
 import (
+	"encoding/json"
 	"errors"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -33,45 +33,82 @@ func convertToInt(val interface{}) (int, error) {
 		return v, nil
 	} else if v, ok := val.(*int); ok {
 		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		n, err := jsonNumberToInt(v, strconv.IntSize)
+		return int(n), err
 	}
 	return 0, errors.New(ErrCnvFailed)
 }
 
 
-func convertNumericStrToInt(val interface{}) (int, error) {
+// numbers decoded from JSON documents are kept as json.Number,
+// they are accepted by the int converters as long as they are
+// whole numbers which fit in the requested size
+func jsonNumberToInt(val json.Number, bitSize int) (int64, error) {
+	n, err := strconv.ParseInt(string(val), 10, bitSize)
+	if err != nil {
+		return 0, numErr(err)
+	}
+	return n, nil
+}
+
+// parses a numeric string, such as "8080", "-5", "0x1F" or "1_000",
+// see parseIntLiteral()
+func convertNumericStrToInt(val interface{}, bitSize int) (int64, error) {
 	str, err := convertToString(val)
 	if err != nil {
 		return 0, err
 	}
-	if strings.Index(str, "0") == 0 {
-		return 0, errors.New("numeric string starts with zero")
-	}
-	rg := regexp.MustCompile(`^[0-9]+$`)
-	if rg.Match([]byte(str)) {
-		numInt, err := strconv.Atoi(str)
-		if err != nil {
-			return 0, err
-		}
-		return numInt, nil
+	return parseIntLiteral(str, bitSize)
+}
+
+// BoolValues is the vocabulary of the strings read as booleans
+// by GetStringAsBool(), the words are compared case-insensitively
+type BoolValues struct {
+	True  []string
+	False []string
+}
+
+// Returns the default vocabulary: 1, true, t, yes, y, on and enabled,
+// and their opposites 0, false, f, no, n, off and disabled
+func DefaultBoolValues() BoolValues {
+	return BoolValues{
+		True:  []string{"1", "true", "t", "yes", "y", "on", "enabled"},
+		False: []string{"0", "false", "f", "no", "n", "off", "disabled"},
 	}
-	return 0, errors.New(ErrCnvFailed)
 }
 
+var defaultBoolValues = DefaultBoolValues()
+
 func convertNumericStrToBool(val interface{}) (bool, error) {
+	return convertStrToBool(val, defaultBoolValues)
+}
+
+// accepts a bool as it is, so that a flag of SrcNameArgs ("on"), a
+// variable of SrcNameEnv ("Yes") and a boolean of a JSON or YAML
+// document are read the same way
+func convertStrToBool(val interface{}, values BoolValues) (bool, error) {
+	if b, err := convertToBool(val); err == nil {
+		return b, nil
+	}
 	str, err := convertToString(val)
 	if err != nil {
 		return false, err
 	}
-	if str == "0" || str == "false" {
-		return false, nil
-	} else if str == "1" || str == "true" {
-		return true, nil
+	str = strings.TrimSpace(str)
+	for _, word := range values.True {
+		if strings.EqualFold(str, word) {
+			return true, nil
+		}
+	}
+	for _, word := range values.False {
+		if strings.EqualFold(str, word) {
+			return false, nil
+		}
 	}
 	return false, errors.New(ErrCnvFailed)
 }
 
-
-
 func convertToBool(val interface{}) (bool, error) {
 	if v, ok := val.(bool); ok {
 		return v, nil
@@ -85,6 +122,9 @@ func convertToInt32(val interface{}) (int32, error) {
 		return v, nil
 	} else if v, ok := val.(*int32); ok {
 		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		n, err := jsonNumberToInt(v, 32)
+		return int32(n), err
 	}
 	return 0, errors.New(ErrCnvFailed)
 }
@@ -93,6 +133,8 @@ func convertToInt64(val interface{}) (int64, error) {
 		return v, nil
 	} else if v, ok := val.(*int64); ok {
 		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		return jsonNumberToInt(v, 64)
 	}
 	return 0, errors.New(ErrCnvFailed)
 }
@@ -102,6 +144,9 @@ func convertToInt8(val interface{}) (int8, error) {
 		return v, nil
 	} else if v, ok := val.(*int8); ok {
 		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		n, err := jsonNumberToInt(v, 8)
+		return int8(n), err
 	}
 	return 0, errors.New(ErrCnvFailed)
 }
@@ -110,6 +155,9 @@ func convertToInt16(val interface{}) (int16, error) {
 		return v, nil
 	} else if v, ok := val.(*int16); ok {
 		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		n, err := jsonNumberToInt(v, 16)
+		return int16(n), err
 	}
 	return 0, errors.New(ErrCnvFailed)
 }
@@ -121,4 +169,4 @@ func convertToTimeDuration(val interface{}) (*time.Duration, error) {
 		return v, nil
 	}
 	return nil, errors.New(ErrCnvFailed)
-}
\ No newline at end of file
+}
@@ -0,0 +1,151 @@
+package dyanmic_params
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// returns val as an int64, a uint64 or a float64, whatever its
+// numeric type is (including named types and pointers), so that
+// the lenient converters only deal with these three
+func numericValue(val interface{}) (interface{}, error) {
+	if v, ok := val.(json.Number); ok {
+		if n, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return n, nil
+		}
+		if n, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return n, nil
+		}
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return nil, numErr(err)
+		}
+		return f, nil
+	}
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, errors.New(ErrCnvFailed)
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	}
+	return nil, errors.New(ErrCnvFailed)
+}
+
+// converts any number to a signed integer of bitSize bits, a float
+// with a fractional part fails with ErrTruncated, and a number out
+// of the range of the type with ErrOverflow
+func lenientInt(val interface{}, bitSize int) (int64, error) {
+	n, err := numericValue(val)
+	if err != nil {
+		return 0, err
+	}
+	min := int64(-1) << (bitSize - 1)
+	max := -(min + 1)
+	switch v := n.(type) {
+	case int64:
+		if v < min || v > max {
+			return 0, errors.New(ErrOverflow)
+		}
+		return v, nil
+	case uint64:
+		if v > uint64(max) {
+			return 0, errors.New(ErrOverflow)
+		}
+		return int64(v), nil
+	}
+	f, err := checkFinite(n.(float64))
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) {
+		return 0, errors.New(ErrTruncated)
+	}
+	if f < float64(min) || f >= -float64(min) {
+		return 0, errors.New(ErrOverflow)
+	}
+	return int64(f), nil
+}
+
+// like lenientInt(), negative numbers fail with ErrOverflow
+func lenientUint(val interface{}, bitSize int) (uint64, error) {
+	n, err := numericValue(val)
+	if err != nil {
+		return 0, err
+	}
+	max := uint64(math.MaxUint64) >> (64 - bitSize)
+	switch v := n.(type) {
+	case int64:
+		if v < 0 || uint64(v) > max {
+			return 0, errors.New(ErrOverflow)
+		}
+		return uint64(v), nil
+	case uint64:
+		if v > max {
+			return 0, errors.New(ErrOverflow)
+		}
+		return v, nil
+	}
+	f, err := checkFinite(n.(float64))
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) {
+		return 0, errors.New(ErrTruncated)
+	}
+	if f < 0 || f >= math.Ldexp(1, bitSize) {
+		return 0, errors.New(ErrOverflow)
+	}
+	return uint64(f), nil
+}
+
+// converts any number to a float of bitSize bits. An integer which
+// the float cannot hold exactly (above 2^53 for float64) fails with
+// ErrTruncated, while a float is rounded to float32 as Go does, and
+// only fails with ErrOverflow when it is out of its range
+func lenientFloat(val interface{}, bitSize int) (float64, error) {
+	n, err := numericValue(val)
+	if err != nil {
+		return 0, err
+	}
+	switch v := n.(type) {
+	case int64:
+		f := roundFloat(float64(v), bitSize)
+		if f >= 0x1p63 || int64(f) != v {
+			return 0, errors.New(ErrTruncated)
+		}
+		return f, nil
+	case uint64:
+		f := roundFloat(float64(v), bitSize)
+		if f >= 0x1p64 || uint64(f) != v {
+			return 0, errors.New(ErrTruncated)
+		}
+		return f, nil
+	}
+	f, err := checkFinite(n.(float64))
+	if err != nil {
+		return 0, err
+	}
+	if bitSize == 32 && math.Abs(f) > math.MaxFloat32 {
+		return 0, errors.New(ErrOverflow)
+	}
+	return roundFloat(f, bitSize), nil
+}
+
+func roundFloat(f float64, bitSize int) float64 {
+	if bitSize == 32 {
+		return float64(float32(f))
+	}
+	return f
+}
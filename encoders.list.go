@@ -0,0 +1,166 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	ErrUnterminatedQuote = "list has an unterminated quote"
+	ErrTextAfterQuote    = "list has text after a closing quote"
+)
+
+// ListOptions controls how a string is split into a list by the
+// slice accessors (GetAsStringSlice(), GetAsIntSlice() ...), and
+// into a map by the map accessors (GetAsStringMap() ...)
+type ListOptions struct {
+	// the separator of the items, "," by default
+	Separator string
+
+	// the characters an item can be quoted with, so that it may hold
+	// the separator ("'a,b',c" is a list of two), `"'` by default
+	Quotes string
+
+	// treats quotes as any other character
+	DisableQuotes bool
+
+	// the separator of a key and its value, in the strings read by
+	// the map accessors ("env=prod,team=core"), "=" by default
+	KeyValueSeparator string
+}
+
+func (o ListOptions) separator() string {
+	if o.Separator == "" {
+		return ","
+	}
+	return o.Separator
+}
+
+func (o ListOptions) keyValueSeparator() string {
+	if o.KeyValueSeparator == "" {
+		return "="
+	}
+	return o.KeyValueSeparator
+}
+
+func (o ListOptions) quotes() string {
+	if o.DisableQuotes {
+		return ""
+	} else if o.Quotes == "" {
+		return `"'`
+	}
+	return o.Quotes
+}
+
+// returns the items of val, which is either a slice or an array of
+// any type (e.g. []interface{} decoded from JSON or YAML), kept as it
+// is, or a string which is split with splitDelimited()
+func splitList(val interface{}, opts ListOptions) ([]interface{}, error) {
+	if str, err := convertToString(val); err == nil {
+		items, err := splitDelimited(str, opts)
+		if err != nil {
+			return nil, err
+		}
+		list := make([]interface{}, len(items))
+		for i, item := range items {
+			list[i] = item
+		}
+		return list, nil
+	}
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, errors.New(ErrCnvFailed)
+	}
+	list := make([]interface{}, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+	return list, nil
+}
+
+// splits str on the separator, trimming the spaces around the items.
+// A quoted item is kept as it is, separators and spaces included, and
+// must be followed by a separator ('a'b is malformed). An empty (or
+// blank) string is an empty list
+func splitDelimited(str string, opts ListOptions) ([]string, error) {
+	items := []string{}
+	if strings.TrimSpace(str) == "" {
+		return items, nil
+	}
+	sep, quotes := opts.separator(), opts.quotes()
+	var item strings.Builder
+	var quote byte
+	quoted := false
+	flush := func() {
+		if quoted {
+			items = append(items, item.String())
+		} else {
+			items = append(items, strings.TrimSpace(item.String()))
+		}
+		item.Reset()
+		quoted = false
+	}
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			} else {
+				item.WriteByte(ch)
+			}
+		case strings.HasPrefix(str[i:], sep):
+			flush()
+			i += len(sep) - 1
+		case !quoted && strings.IndexByte(quotes, ch) >= 0 && strings.TrimSpace(item.String()) == "":
+			item.Reset()
+			quote, quoted = ch, true
+		case quoted && (ch == ' ' || ch == '\t'):
+			// spaces between the closing quote and the separator
+		case quoted:
+			return nil, errors.New(ErrTextAfterQuote)
+		default:
+			item.WriteByte(ch)
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New(ErrUnterminatedQuote)
+	}
+	flush()
+	return items, nil
+}
+
+// items which are strings are parsed, other ones are converted
+// from any numeric type, as long as they fit
+func convertItemToInt(item interface{}, bitSize int) (int64, error) {
+	if str, ok := item.(string); ok {
+		return parseIntLiteral(str, bitSize)
+	}
+	return lenientInt(item, bitSize)
+}
+
+func convertItemToFloat(item interface{}, bitSize int) (float64, error) {
+	if str, ok := item.(string); ok {
+		return parseFloat(str, bitSize)
+	}
+	return lenientFloat(item, bitSize)
+}
+
+func convertItemToDuration(item interface{}, unit time.Duration) (time.Duration, error) {
+	if str, ok := item.(string); ok {
+		return parseDurationIn(str, unit)
+	}
+	if _, ok := item.(time.Duration); !ok && unit != 0 {
+		return durationFromInt(item, unit)
+	}
+	d, err := convertToTimeDuration(item)
+	if err != nil {
+		return 0, err
+	}
+	return *d, nil
+}
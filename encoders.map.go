@@ -0,0 +1,74 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const ErrMapEntry = "map entry is not a key and a value"
+
+// returns the entries of val, which is either a map of any type (e.g.
+// map[string]interface{} decoded from JSON, or a map[interface{}]interface{}
+// as produced by YAML decoders), its keys formatted as strings, or a
+// string of key=value pairs ("env=prod,team=core"), split with
+// splitDelimited() and the key and value separator of opts
+func splitMap(name string, val interface{}, opts ListOptions) (map[string]interface{}, error) {
+	if str, err := convertToString(val); err == nil {
+		entries, err := splitKeyValues(str, opts)
+		if err != nil {
+			return nil, &ParamError{Key: name, Err: err}
+		}
+		return entries, nil
+	}
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Map {
+		return nil, errors.New(ErrCnvFailed)
+	}
+	entries := make(map[string]interface{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		entries[fmt.Sprint(iter.Key().Interface())] = iter.Value().Interface()
+	}
+	return entries, nil
+}
+
+// parses "env=prod,team=core", an entry without a key and a value is
+// an error, and a repeated key keeps its last value. Quote the whole
+// entry for a value holding the separator ("'hosts=a,b',team=core")
+func splitKeyValues(str string, opts ListOptions) (map[string]interface{}, error) {
+	items, err := splitDelimited(str, opts)
+	if err != nil {
+		return nil, err
+	}
+	sep := opts.keyValueSeparator()
+	entries := make(map[string]interface{}, len(items))
+	for _, item := range items {
+		if item == "" {
+			continue
+		}
+		idx := strings.Index(item, sep)
+		if idx <= 0 {
+			return nil, fmt.Errorf("%s: %q", ErrMapEntry, item)
+		}
+		key := strings.TrimSpace(item[:idx])
+		entries[key] = strings.TrimSpace(item[idx+len(sep):])
+	}
+	return entries, nil
+}
+
+// returns the keys of entries in order, so that the
+// first error of a map is always the same
+func sortedKeys(entries map[string]interface{}) []string {
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
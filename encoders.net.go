@@ -0,0 +1,157 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	ErrSchemeNotAllowed = "URL scheme is not allowed"
+	ErrPortRange        = "port is out of range"
+	ErrNotIPAddress     = "host is not an IP address"
+	ErrURLWithoutHost   = "URL has no host"
+)
+
+// accepts a *url.URL, a url.URL, or an absolute URL string, which
+// must have a host ("https://" has none) unless it is opaque, such as
+// "mailto:ops@example.com". With schemes, the scheme must be one of
+// them (compared case-insensitively)
+func convertToURL(val interface{}, schemes []string) (*url.URL, error) {
+	var u *url.URL
+	if v, ok := val.(*url.URL); ok && v != nil {
+		u = v
+	} else if v, ok := val.(url.URL); ok {
+		u = &v
+	} else {
+		str, err := convertToString(val)
+		if err != nil {
+			return nil, err
+		}
+		u, err = url.Parse(strings.TrimSpace(str))
+		if err != nil {
+			return nil, errors.New(ErrCnvFailed)
+		}
+	}
+	if u.Scheme == "" {
+		return nil, errors.New(ErrSchemeNotAllowed)
+	}
+	if u.Host == "" && !isOpaqueURL(u) {
+		return nil, errors.New(ErrURLWithoutHost)
+	}
+	if len(schemes) == 0 {
+		return u, nil
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return u, nil
+		}
+	}
+	return nil, errors.New(ErrSchemeNotAllowed)
+}
+
+// reports whether u is an opaque URL ("mailto:ops@example.com"),
+// rather than a host:port ("localhost:8080"), which url.Parse()
+// reads as the scheme "localhost" and the opaque part "8080"
+func isOpaqueURL(u *url.URL) bool {
+	if u.Opaque == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(u.Opaque, 10, 64)
+	return err != nil
+}
+
+// accepts a net.IP, or an IPv4 or IPv6 address string
+func convertToIP(val interface{}) (net.IP, error) {
+	if v, ok := val.(net.IP); ok && v != nil {
+		return v, nil
+	}
+	str, err := convertToString(val)
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(strings.TrimSpace(str))
+	if ip == nil {
+		return nil, errors.New(ErrCnvFailed)
+	}
+	return ip, nil
+}
+
+// accepts a *net.IPNet, a net.IPNet, or a CIDR string ("10.0.0.0/8").
+// A single address ("10.0.0.1") is read as the network of that
+// address alone (10.0.0.1/32), as allow-lists often mix both
+func convertToIPNet(val interface{}) (*net.IPNet, error) {
+	if v, ok := val.(*net.IPNet); ok && v != nil {
+		return v, nil
+	} else if v, ok := val.(net.IPNet); ok {
+		return &v, nil
+	}
+	str, err := convertToString(val)
+	if err != nil {
+		return nil, err
+	}
+	str = strings.TrimSpace(str)
+	if !strings.Contains(str, "/") {
+		ip := net.ParseIP(str)
+		if ip == nil {
+			return nil, errors.New(ErrCnvFailed)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(str)
+	if err != nil {
+		return nil, errors.New(ErrCnvFailed)
+	}
+	return ipNet, nil
+}
+
+// splits "host:port" ("[::1]:80" for IPv6) without resolving
+// anything, the port must be a number from 0 to 65535
+func splitHostPort(val interface{}) (string, int, error) {
+	str, err := convertToString(val)
+	if err != nil {
+		return "", 0, err
+	}
+	host, portStr, err := net.SplitHostPort(strings.TrimSpace(str))
+	if err != nil {
+		return "", 0, errors.New(ErrCnvFailed)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, errors.New(ErrCnvFailed)
+	}
+	if port < 0 || port > 65535 {
+		return "", 0, errors.New(ErrPortRange)
+	}
+	return host, port, nil
+}
+
+// accepts a *net.TCPAddr, or "ip:port", the host must be an IP
+// address (with an optional IPv6 zone) or empty, names are not
+// resolved. An empty host (":8080") gives a nil IP, as for listeners
+func convertToTCPAddr(val interface{}) (*net.TCPAddr, error) {
+	if v, ok := val.(*net.TCPAddr); ok && v != nil {
+		return v, nil
+	}
+	host, port, err := splitHostPort(val)
+	if err != nil {
+		return nil, err
+	}
+	addr := &net.TCPAddr{Port: port}
+	if host == "" {
+		return addr, nil
+	}
+	if i := strings.LastIndexByte(host, '%'); i > 0 {
+		host, addr.Zone = host[:i], host[i+1:]
+	}
+	if addr.IP = net.ParseIP(host); addr.IP == nil {
+		return nil, errors.New(ErrNotIPAddress)
+	}
+	return addr, nil
+}
@@ -0,0 +1,208 @@
+package dyanmic_params
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	ErrOverflow  = "value is out of the range of the type"
+	ErrNotFinite = "value is NaN or infinite"
+	ErrTruncated = "value cannot be held exactly by the type"
+
+	ErrLeadingZero = "numeric string starts with zero"
+)
+
+// the size of uintptr in bits
+const uintptrSize = 32 << (^uintptr(0) >> 63)
+
+// maps the errors of strconv, so that a value which is too
+// large for the type is told apart from a malformed one
+func numErr(err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return errors.New(ErrOverflow)
+	}
+	return errors.New(ErrCnvFailed)
+}
+
+// parses the decimal digits of an unsigned number, as found in a
+// json.Number, "-1" is malformed
+// and a number larger than the type is an overflow
+func parseUint(str string, bitSize int) (uint64, error) {
+	n, err := strconv.ParseUint(str, 10, bitSize)
+	if err != nil {
+		return 0, numErr(err)
+	}
+	return n, nil
+}
+
+// parses an integer written as a Go literal: signed, in base 10,
+// 16 (0x), 8 (0o) or 2 (0b), with optional underscores between the
+// digits ("1_000_000"). A legacy octal literal ("0123") is rejected,
+// since it is more often meant as a decimal with a leading zero
+func parseIntLiteral(str string, bitSize int) (int64, error) {
+	if hasLeadingZero(str) {
+		return 0, errors.New(ErrLeadingZero)
+	}
+	n, err := strconv.ParseInt(str, 0, bitSize)
+	if err != nil {
+		return 0, numErr(err)
+	}
+	return n, nil
+}
+
+// like parseIntLiteral(), a "+" sign is accepted but "-1" is malformed
+func parseUintLiteral(str string, bitSize int) (uint64, error) {
+	if hasLeadingZero(str) {
+		return 0, errors.New(ErrLeadingZero)
+	}
+	n, err := strconv.ParseUint(strings.TrimPrefix(str, "+"), 0, bitSize)
+	if err != nil {
+		return 0, numErr(err)
+	}
+	return n, nil
+}
+
+// reports if str, after its sign, is a zero followed by a digit
+// or an underscore, which Go reads as an octal number
+func hasLeadingZero(str string) bool {
+	if str != "" && (str[0] == '-' || str[0] == '+') {
+		str = str[1:]
+	}
+	return len(str) > 1 && str[0] == '0' && (str[1] == '_' || (str[1] >= '0' && str[1] <= '9'))
+}
+
+// parses a float, rejecting NaN and infinities written literally
+// ("NaN", "+Inf") with ErrNotFinite, and numbers out of the range
+// of bitSize ("1e400") with ErrOverflow
+func parseFloat(str string, bitSize int) (float64, error) {
+	f, err := strconv.ParseFloat(str, bitSize)
+	if err != nil {
+		return 0, numErr(err)
+	}
+	return checkFinite(f)
+}
+
+func checkFinite(f float64) (float64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, errors.New(ErrNotFinite)
+	}
+	return f, nil
+}
+
+func convertToUint(val interface{}) (uint, error) {
+	if v, ok := val.(uint); ok {
+		return v, nil
+	} else if v, ok := val.(*uint); ok {
+		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		n, err := parseUint(string(v), strconv.IntSize)
+		return uint(n), err
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
+
+func convertToUint8(val interface{}) (uint8, error) {
+	if v, ok := val.(uint8); ok {
+		return v, nil
+	} else if v, ok := val.(*uint8); ok {
+		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		n, err := parseUint(string(v), 8)
+		return uint8(n), err
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
+
+func convertToUint16(val interface{}) (uint16, error) {
+	if v, ok := val.(uint16); ok {
+		return v, nil
+	} else if v, ok := val.(*uint16); ok {
+		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		n, err := parseUint(string(v), 16)
+		return uint16(n), err
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
+
+func convertToUint32(val interface{}) (uint32, error) {
+	if v, ok := val.(uint32); ok {
+		return v, nil
+	} else if v, ok := val.(*uint32); ok {
+		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		n, err := parseUint(string(v), 32)
+		return uint32(n), err
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
+
+func convertToUint64(val interface{}) (uint64, error) {
+	if v, ok := val.(uint64); ok {
+		return v, nil
+	} else if v, ok := val.(*uint64); ok {
+		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		return parseUint(string(v), 64)
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
+
+func convertToUintptr(val interface{}) (uintptr, error) {
+	if v, ok := val.(uintptr); ok {
+		return v, nil
+	} else if v, ok := val.(*uintptr); ok {
+		return *v, nil
+	} else if v, ok := val.(json.Number); ok {
+		n, err := parseUint(string(v), uintptrSize)
+		return uintptr(n), err
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
+
+// NaN and infinities are rejected, even when they are stored as
+// they are, since no param is expected to hold one on purpose
+func convertToFloat32(val interface{}) (float32, error) {
+	if v, ok := val.(float32); ok {
+		f, err := checkFinite(float64(v))
+		return float32(f), err
+	} else if v, ok := val.(*float32); ok {
+		f, err := checkFinite(float64(*v))
+		return float32(f), err
+	} else if v, ok := val.(json.Number); ok {
+		f, err := parseFloat(string(v), 32)
+		return float32(f), err
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
+
+func convertToFloat64(val interface{}) (float64, error) {
+	if v, ok := val.(float64); ok {
+		return checkFinite(v)
+	} else if v, ok := val.(*float64); ok {
+		return checkFinite(*v)
+	} else if v, ok := val.(json.Number); ok {
+		return parseFloat(string(v), 64)
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
+
+func convertNumericStrToUint(val interface{}, bitSize int) (uint64, error) {
+	str, err := convertToString(val)
+	if err != nil {
+		return 0, err
+	}
+	return parseUintLiteral(str, bitSize)
+}
+
+func convertNumericStrToFloat(val interface{}, bitSize int) (float64, error) {
+	str, err := convertToString(val)
+	if err != nil {
+		return 0, err
+	}
+	return parseFloat(str, bitSize)
+}
@@ -0,0 +1,292 @@
+package dyanmic_params
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+var timeType = reflect.TypeOf(time.Time{})
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// the types assignValue() converts the way the network accessors
+// (GetAsURL(), GetAsIP() ...) do, a pointer to them is allocated
+var netConverters = map[reflect.Type]func(raw interface{}) (interface{}, error){
+	reflect.TypeOf(url.URL{}): func(raw interface{}) (interface{}, error) {
+		u, err := convertToURL(raw, nil)
+		if err != nil {
+			return nil, err
+		}
+		return *u, nil
+	},
+	reflect.TypeOf(net.IP{}): func(raw interface{}) (interface{}, error) {
+		return convertToIP(raw)
+	},
+	reflect.TypeOf(net.IPNet{}): func(raw interface{}) (interface{}, error) {
+		n, err := convertToIPNet(raw)
+		if err != nil {
+			return nil, err
+		}
+		return *n, nil
+	},
+	reflect.TypeOf(net.TCPAddr{}): func(raw interface{}) (interface{}, error) {
+		a, err := convertToTCPAddr(raw)
+		if err != nil {
+			return nil, err
+		}
+		return *a, nil
+	},
+}
+
+// stores raw into dst, converting it to the type of dst. raw can be
+// a value of the same type, a convertible number, or a string (as
+// given by args and env) which is parsed into the type of dst, using
+// the settings of the instance (e.g. the words of SetBoolValues())
+func assignValue(dst reflect.Value, raw interface{}, opts *paramsOptions) error {
+	if raw == nil {
+		return errors.New(ErrCnvFailed)
+	}
+	rv := reflect.ValueOf(raw)
+	if rv.Type().AssignableTo(dst.Type()) {
+		dst.Set(rv)
+		return nil
+	}
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return errors.New(ErrCnvFailed)
+		}
+		return assignValue(dst, rv.Elem().Interface(), opts)
+	}
+	if dst.Kind() == reflect.Ptr {
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignValue(elem.Elem(), raw, opts); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+	if dst.Type() == timeType {
+		t, err := convertToTime(raw, opts.time)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if dst.Type() == durationType {
+		return assignDuration(dst, raw, opts)
+	}
+	if conv, ok := netConverters[dst.Type()]; ok {
+		v, err := conv(raw)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(v))
+		return nil
+	}
+	if isTextUnmarshaler(dst.Type()) {
+		// a type with its own text format, even a []byte one,
+		// must not get the raw text
+		if rv.Kind() != reflect.String {
+			return errors.New(ErrCnvFailed)
+		}
+		val := reflect.New(dst.Type())
+		if err := val.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(rv.String())); err != nil {
+			return err
+		}
+		dst.Set(val.Elem())
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		if rv.Kind() == reflect.String {
+			dst.SetString(rv.String())
+			return nil
+		}
+	case reflect.Bool:
+		if s, ok := raw.(string); ok {
+			b, err := convertStrToBool(s, opts.boolValues())
+			if err != nil {
+				return err
+			}
+			dst.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := reflectInt(rv)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowInt(n) {
+			return errors.New(ErrCnvFailed)
+		}
+		dst.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := reflectUint(rv)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowUint(n) {
+			return errors.New(ErrCnvFailed)
+		}
+		dst.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := reflectFloat(rv)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowFloat(f) {
+			return errors.New(ErrCnvFailed)
+		}
+		dst.SetFloat(f)
+		return nil
+	case reflect.Slice:
+		return assignSlice(dst, rv, opts)
+	case reflect.Map:
+		return assignMap(dst, rv, opts)
+	}
+	return errors.New(ErrCnvFailed)
+}
+
+// reports whether a pointer to t parses itself from text
+func isTextUnmarshaler(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+// durations are parsed from strings, and read from integers
+// when the instance has a unit, see SetDurationUnit()
+func assignDuration(dst reflect.Value, raw interface{}, opts *paramsOptions) error {
+	var d time.Duration
+	if s, err := convertToString(raw); err == nil {
+		d, err = parseDurationIn(s, opts.durationUnit)
+		if err != nil {
+			return err
+		}
+	} else if opts.durationUnit != 0 {
+		d, err = durationFromInt(raw, opts.durationUnit)
+		if err != nil {
+			return err
+		}
+	} else {
+		return err
+	}
+	dst.SetInt(int64(d))
+	return nil
+}
+
+// lists are read from slices, and from strings split
+// with the ListOptions of the instance
+func assignSlice(dst reflect.Value, rv reflect.Value, opts *paramsOptions) error {
+	if rv.Kind() == reflect.String {
+		if dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes([]byte(rv.String()))
+			return nil
+		}
+		items, err := splitDelimited(rv.String(), opts.list)
+		if err != nil {
+			return err
+		}
+		list := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := assignValue(list.Index(i), item, opts); err != nil {
+				return err
+			}
+		}
+		dst.Set(list)
+		return nil
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return errors.New(ErrCnvFailed)
+	}
+	list := reflect.MakeSlice(dst.Type(), rv.Len(), rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		if err := assignValue(list.Index(i), rv.Index(i).Interface(), opts); err != nil {
+			return err
+		}
+	}
+	dst.Set(list)
+	return nil
+}
+
+func assignMap(dst reflect.Value, rv reflect.Value, opts *paramsOptions) error {
+	if rv.Kind() == reflect.String {
+		entries, err := splitKeyValues(rv.String(), opts.list)
+		if err != nil {
+			return err
+		}
+		rv = reflect.ValueOf(entries)
+	}
+	if rv.Kind() != reflect.Map || dst.Type().Key().Kind() != reflect.String {
+		return errors.New(ErrCnvFailed)
+	}
+	mp := reflect.MakeMapWithSize(dst.Type(), rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := reflect.New(dst.Type().Key()).Elem()
+		key.SetString(fmt.Sprint(iter.Key().Interface()))
+		val := reflect.New(dst.Type().Elem()).Elem()
+		if err := assignValue(val, iter.Value().Interface(), opts); err != nil {
+			return err
+		}
+		mp.SetMapIndex(key, val)
+	}
+	dst.Set(mp)
+	return nil
+}
+
+func reflectInt(rv reflect.Value) (int64, error) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > 1<<63-1 {
+			return 0, errors.New(ErrCnvFailed)
+		}
+		return int64(rv.Uint()), nil
+	case reflect.String:
+		return parseIntLiteral(rv.String(), 64)
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
+
+func reflectUint(rv reflect.Value) (uint64, error) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() < 0 {
+			return 0, errors.New(ErrCnvFailed)
+		}
+		return uint64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), nil
+	case reflect.String:
+		return parseUintLiteral(rv.String(), 64)
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
+
+func reflectFloat(rv reflect.Value) (float64, error) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		f, err := strconv.ParseFloat(rv.String(), 64)
+		if err != nil {
+			return 0, errors.New(ErrCnvFailed)
+		}
+		return f, nil
+	}
+	return 0, errors.New(ErrCnvFailed)
+}
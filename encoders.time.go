@@ -0,0 +1,117 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// TimeOptions controls how GetAsTime() and GetAsDate() read times
+type TimeOptions struct {
+	// the layouts strings are parsed with, in order. By default
+	// RFC 3339 (with or without fractional seconds) and dates
+	// ("2006-01-02")
+	Layouts []string
+
+	// the location of the times whose layout has no zone (such as
+	// dates), and of the dates returned by GetAsDate(), UTC by default
+	Location *time.Location
+
+	// the unit of the numbers read as Unix times,
+	// time.Second by default, or time.Millisecond
+	UnixUnit time.Duration
+}
+
+// the layouts of TimeOptions, when none is given
+var defaultTimeLayouts = []string{time.RFC3339Nano, "2006-01-02"}
+
+func (o TimeOptions) layouts() []string {
+	if len(o.Layouts) == 0 {
+		return defaultTimeLayouts
+	}
+	return o.Layouts
+}
+
+func (o TimeOptions) location() *time.Location {
+	if o.Location == nil {
+		return time.UTC
+	}
+	return o.Location
+}
+
+func (o TimeOptions) unixUnit() time.Duration {
+	if o.UnixUnit <= 0 {
+		return time.Second
+	}
+	return o.UnixUnit
+}
+
+// accepts a time.Time, a string in one of the layouts of opts, or
+// an integer of any type (or a numeric string) as a Unix time
+func convertToTime(val interface{}, opts TimeOptions) (time.Time, error) {
+	if v, ok := val.(time.Time); ok {
+		return v, nil
+	} else if v, ok := val.(*time.Time); ok && v != nil {
+		return *v, nil
+	}
+	if str, err := convertToString(val); err == nil {
+		str = strings.TrimSpace(str)
+		for _, layout := range opts.layouts() {
+			if t, err := time.ParseInLocation(layout, str, opts.location()); err == nil {
+				return t, nil
+			}
+		}
+		n, err := parseIntLiteral(str, 64)
+		if err != nil {
+			return time.Time{}, errors.New(ErrCnvFailed)
+		}
+		return unixTime(n, opts.unixUnit(), opts.location())
+	}
+	n, err := lenientInt(val, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return unixTime(n, opts.unixUnit(), opts.location())
+}
+
+// returns the time n units after the Unix epoch, in loc
+func unixTime(n int64, unit time.Duration, loc *time.Location) (time.Time, error) {
+	if unit >= time.Second {
+		perUnit := int64(unit / time.Second)
+		if n > math.MaxInt64/perUnit || n < math.MinInt64/perUnit {
+			return time.Time{}, errors.New(ErrOverflow)
+		}
+		return time.Unix(n*perUnit, 0).In(loc), nil
+	}
+	perSecond := int64(time.Second / unit)
+	return time.Unix(n/perSecond, n%perSecond*int64(unit)).In(loc), nil
+}
+
+// returns the calendar date of t in loc, at midnight
+func dateOf(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// accepts a *time.Location, or the name of a zone in the IANA
+// database ("Europe/Berlin"), "UTC" or "Local"
+func convertToLocation(val interface{}) (*time.Location, error) {
+	if v, ok := val.(*time.Location); ok && v != nil {
+		return v, nil
+	}
+	str, err := convertToString(val)
+	if err != nil {
+		return nil, err
+	}
+	// LoadLocation() reads "" as UTC, an empty value is an error
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return nil, errors.New(ErrCnvFailed)
+	}
+	loc, err := time.LoadLocation(str)
+	if err != nil {
+		return nil, errors.New(ErrCnvFailed)
+	}
+	return loc, nil
+}
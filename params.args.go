@@ -0,0 +1,99 @@
+package dyanmic_params
+
+// Returns the positional arguments, when the source is SrcNameArgs
+// (or a composite source holding a SourceArgs), otherwise nil
+func (c *DynamicParams) Args() []string {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	if args := c.argsSource(); args != nil {
+		return args.Args()
+	}
+	return nil
+}
+
+// Returns the i-th positional argument, or an empty string
+func (c *DynamicParams) Arg(i int) string {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	if args := c.argsSource(); args != nil {
+		return args.Arg(i)
+	}
+	return ""
+}
+
+// Returns the number of positional arguments
+func (c *DynamicParams) NArg() int {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	if args := c.argsSource(); args != nil {
+		return args.NArg()
+	}
+	return 0
+}
+
+// Returns the name of the selected subcommand, or an empty string
+func (c *DynamicParams) CommandName() string {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	if args := c.argsSource(); args != nil {
+		return args.CommandName()
+	}
+	return ""
+}
+
+// Returns the params of the selected subcommand, or nil if there is
+// none. The returned instance shares the lock of c, its keys are the
+// flags of the subcommand, falling back to the global flags, and its
+// Args() are the positional arguments given after the subcommand.
+//
+// If the source is composite, the other sources are kept, so a key
+// missing from the command line still comes from env, files etc.
+func (c *DynamicParams) Command() *DynamicParams {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	args := c.argsSource()
+	if args == nil || args.Command() == nil {
+		return nil
+	}
+	var source ParamsSource = args.Command()
+	if comp, ok := c.source.(*SourceComposite); ok {
+		sources := make([]ParamsSource, len(comp.sources))
+		for i, src := range comp.sources {
+			if src == ParamsSource(args) {
+				src = args.Command()
+			}
+			sources[i] = src
+		}
+		source = NewSourceComposite(sources...)
+	}
+	return &DynamicParams{
+		Mx:     c.Mx,
+		source: source,
+		opts:   c.opts,
+	}
+}
+
+// returns the SourceArgs behind c, if any
+func (c *DynamicParams) argsSource() *SourceArgs {
+	switch src := c.source.(type) {
+	case *SourceArgs:
+		return src
+	case *SourceComposite:
+		for _, s := range src.sources {
+			if args, ok := s.(*SourceArgs); ok {
+				return args
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// struct tags understood by Bind() and SetFromStruct()
+const (
+	TagParam    = "param"
+	TagDefault  = "default"
+	TagRequired = "required"
+)
+
+// Fills the struct pointed by v from the params. Each exported field
+// is read from the key given in its `param` tag, or, without a tag,
+// from its name in kebab case (MaxConns -> max-conns). A field tagged
+// with `param:"-"` is skipped.
+//
+// Nested structs (and pointers to structs) are filled from the keys
+// under the key of the field, joined with KeyDelimiter, e.g.
+//  type Config struct {
+//      DB struct {
+//          Host    string        `param:"host" default:"localhost"`
+//          Timeout time.Duration `param:"timeout" required:"true"`
+//      } `param:"db"`
+//  }
+// reads db.host and db.timeout. When a key is missing, the value of the
+// `default` tag is used, and if there is no default but the field has
+// `required:"true"`, it is reported as missing.
+//
+// Values are converted to the type of the field, strings (as given by
+// args and env) are parsed, and lists can be given as comma separated
+// strings. All missing and invalid fields are reported at once, as
+// ParamErrors
+func (c *DynamicParams) Bind(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("Bind expects a non-nil pointer to a struct")
+	}
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	var errs ParamErrors
+	c.bindStruct("", "", rv.Elem(), c.options(), &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (c *DynamicParams) bindStruct(prefix, path string, rv reflect.Value, opts *paramsOptions, errs *ParamErrors) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		key, ok := fieldParamKey(field)
+		if !ok {
+			continue
+		}
+		key = joinKey(prefix, key)
+		fieldPath := joinKey(path, field.Name)
+		fv := rv.Field(i)
+
+		if isNestedStruct(field.Type) {
+			if field.Type.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					fv.Set(reflect.New(field.Type.Elem()))
+				}
+				fv = fv.Elem()
+			}
+			c.bindStruct(key, fieldPath, fv, opts, errs)
+			continue
+		}
+
+		var raw interface{}
+		if v, ok := c.lookup(key); ok {
+			raw = v
+		} else if def, ok := field.Tag.Lookup(TagDefault); ok {
+			raw = def
+		} else if field.Tag.Get(TagRequired) == "true" {
+			*errs = append(*errs, &ParamError{Key: key, Field: fieldPath, Err: errors.New(ErrRequired)})
+			continue
+		} else {
+			continue
+		}
+		if err := assignValue(fv, raw, opts); err != nil {
+			*errs = append(*errs, &ParamError{Key: key, Field: fieldPath, Err: err})
+		}
+	}
+}
+
+// The opposite of Bind(): sets a param for each exported field of the
+// struct v (or pointer to struct), using the same keys Bind() reads, so
+// nested structs are flattened into keys joined with KeyDelimiter.
+// Nil pointers are skipped, and other values are set as they are
+func (c *DynamicParams) SetFromStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return errors.New("SetFromStruct expects a struct or a non-nil pointer to a struct")
+	}
+	if c.Mx != nil {
+		c.Mx.Lock()
+		defer c.Mx.Unlock()
+	}
+	c.setStruct("", rv)
+	return nil
+}
+
+func (c *DynamicParams) setStruct(prefix string, rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		key, ok := fieldParamKey(field)
+		if !ok {
+			continue
+		}
+		key = joinKey(prefix, key)
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if isNestedStruct(fv.Type()) {
+			c.setStruct(key, fv)
+			continue
+		}
+		c.source.Add(key, fv.Interface())
+	}
+}
+
+// returns the key of a struct field, and false
+// if the field must be skipped
+func fieldParamKey(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+	tag := field.Tag.Get(TagParam)
+	if tag == "-" {
+		return "", false
+	} else if tag != "" {
+		return tag, true
+	}
+	return kebabCase(field.Name), true
+}
+
+// structs which are values by themselves, and must not be
+// bound field by field, besides the ones in package time
+// and the ones implementing encoding.TextUnmarshaler
+var valueStructTypes = map[reflect.Type]bool{
+	reflect.TypeOf(url.URL{}):     true,
+	reflect.TypeOf(net.IPNet{}):   true,
+	reflect.TypeOf(net.TCPAddr{}): true,
+}
+
+// structs are bound field by field, except the ones
+// which are values by themselves, such as time.Time
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.PkgPath() == "time" || valueStructTypes[t] {
+		return false
+	}
+	return !isTextUnmarshaler(t)
+}
+
+// converts a Go identifier to kebab case:
+// MaxConns -> max-conns, DBHost -> db-host, HTTP2Enabled -> http2-enabled
+func kebabCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				sb.WriteRune('-')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
@@ -0,0 +1,39 @@
+package dyanmic_params
+
+import "strings"
+
+const (
+	ErrRequired = "required param is missing"
+)
+
+// ParamError annotates an error with the key of the param it
+// belongs to, and, when it comes from Bind(), with the struct field
+type ParamError struct {
+	Key   string
+	Field string
+	Err   error
+}
+
+func (e *ParamError) Error() string {
+	msg := e.Key
+	if e.Field != "" {
+		msg += " (" + e.Field + ")"
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// ParamErrors collects the errors of several params, so that
+// all of them can be reported at once
+type ParamErrors []*ParamError
+
+func (e ParamErrors) Error() string {
+	list := make([]string, 0, len(e))
+	for _, err := range e {
+		list = append(list, err.Error())
+	}
+	return strings.Join(list, "; ")
+}
@@ -2,6 +2,7 @@ package dyanmic_params
 
 import (
 	"errors"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -10,6 +11,7 @@ import (
 type DynamicParams struct {
 	Mx *sync.RWMutex
 	source ParamsSource
+	opts *paramsOptions
 }
 
 const (
@@ -18,9 +20,15 @@ const (
 
 // Returns a  new instance of DynamicParams
 //
-// source is the name of the source to use, available sources are:
+// source is the name of the source to use, the built-in sources are:
 // - SrcNameInternal
 // - SrcNameArgs
+// - SrcNameEnv
+// - SrcNameJSON
+// - SrcNameYAML
+// - SrcNameComposite
+// and RegisteredSources() lists them along with the ones
+// added by RegisterSource()
 //
 // If you want to have DynamicParams concurrent safe, you must pass
 // a *sync.Mutex{} as first argument in vars...
@@ -30,34 +38,53 @@ const (
 // vars... it is a list of extra parameters that a source might need. For example,
 // for SrcNameArgs, you need to pass os.Args (or an array of string you want to treat
 // as list of arguments)
+//
+// If the source cannot be created, the error is logged and the instance
+// falls back to an empty SrcNameInternal source
 func NewDynamicParams(source string, vars ...interface{}) *DynamicParams {
 	return createDP(source, vars...)
 }
 
-func createDP(source string, vars ...interface{}) *DynamicParams {
-	var mx *sync.RWMutex
-	var varsNew []interface{}
-	if len(vars) > 0  {
-		if v, ok := vars[0].(*sync.RWMutex); ok {
-			mx = v
-			varsNew = make([]interface{}, len(vars)-1)
-			for i := 0; i < len(vars); i++ {
-				if i == 0 {
-					continue
-				}
-				varsNew = append(varsNew, vars[i])
-			}
-		} else {
-			varsNew = vars
-		}
+// Like NewDynamicParams(), but instead of logging and falling back to
+// an internal source, it returns a *SourceError if the source is unknown,
+// its vars are missing or mistyped, or it fails to load
+func NewDynamicParamsE(source string, vars ...interface{}) (*DynamicParams, error) {
+	mx, varsNew := splitMutex(vars)
+	src, err := NewSourceE(source, varsNew...)
+	if err != nil {
+		return nil, err
 	}
+	return &DynamicParams{
+		Mx: mx,
+		source: src,
+		opts: &paramsOptions{},
+	}, nil
+}
 
+func createDP(source string, vars ...interface{}) *DynamicParams {
+	mx, varsNew := splitMutex(vars)
+	src := NewSource(source, varsNew...)
+	if src == nil {
+		src = NewSourceInternal()
+	}
 	return &DynamicParams{
 		Mx: mx,
-		source: NewSource(source, varsNew...),
+		source: src,
+		opts: &paramsOptions{},
 	}
 }
 
+// separates the optional mutex, passed as the first var,
+// from the vars which belong to the source
+func splitMutex(vars []interface{}) (*sync.RWMutex, []interface{}) {
+	if len(vars) > 0  {
+		if v, ok := vars[0].(*sync.RWMutex); ok {
+			return v, vars[1:]
+		}
+	}
+	return nil, vars
+}
+
 
 // adds a key and value to the active underlying source
 func (c *DynamicParams) Set(name string, value interface{}) *DynamicParams {
@@ -69,24 +96,32 @@ func (c *DynamicParams) Set(name string, value interface{}) *DynamicParams {
 	return c
 }
 
-// Checks to see if the value exists in the underlying source
+// Checks to see if the value exists in the underlying source,
+// name can be a path, see Get()
 func (c *DynamicParams) Has(name string) bool {
 	if c.Mx != nil {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	return c.source.Has(name)
+	_, ok := c.lookup(name)
+	return ok
 }
 
 
 // returns the raw value, if exists, and if not found, returns nil
 // this function is useful for storing struct and custom compound types
+//
+// name can also be a path into a compound value: after
+// Set("db", map[string]interface{}{"primary": ...}), Get("db.primary.host")
+// walks the maps, and "servers[2].port" picks an item of a list.
+// Maps, slices, arrays and exported struct fields can be walked,
+// and every GetAs* method accepts paths as well
 func (c *DynamicParams) Get(name string) interface{} {
 	if c.Mx != nil {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	return c.source.Get(name)
+	return c.get(name)
 }
 
 func (c *DynamicParams) Scan(regex string) map[string]interface{} {
@@ -120,7 +155,7 @@ func (c *DynamicParams) GetAsString(name string) (string, error) {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return "", errors.New(ErrNotFound)
 	}
@@ -132,7 +167,7 @@ func (c *DynamicParams) GetAsBytes(name string) ([]byte, error) {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return nil, errors.New(ErrNotFound)
 	}
@@ -145,7 +180,7 @@ func (c *DynamicParams) GetAsQuotedString(name string) (string, error) {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return "", errors.New(ErrNotFound)
 	}
@@ -157,37 +192,52 @@ func (c *DynamicParams) GetAsQuotedString(name string) (string, error) {
 }
 
 
+// Returns the value if it is an int (or a whole json.Number). With
+// SetLenientNumbers() or Lenient(), any number which fits is accepted
 func (c *DynamicParams) GetAsInt(name string) (int, error) {
 	if c.Mx != nil {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return 0, errors.New(ErrNotFound)
 	}
+	if c.options().lenient {
+		n, err := lenientInt(v, strconv.IntSize)
+		return int(n), err
+	}
 	return convertToInt(v)
 }
 
+// Parses a numeric string as an int: "8080", "-5", "0", as well as Go
+// literals in other bases ("0x1F", "0o755", "0b101") and with digit
+// separators ("1_000_000"). A number too large for the type fails with
+// ErrOverflow, and a decimal with a leading zero ("0123") with
+// ErrLeadingZero
 func (c *DynamicParams) GetStringAsInt(name string) (int, error) {
 	if c.Mx != nil {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return 0, errors.New(ErrNotFound)
 	}
-	return convertNumericStrToInt(v)
+	n, err := convertNumericStrToInt(v, strconv.IntSize)
+	return int(n), err
 }
 
-// parses a string using time.ParseDuration() function
+// parses a string such as "1h30m", as time.ParseDuration() does, with
+// days and weeks ("7d", "2w3d") and ISO 8601 durations ("PT15M",
+// "P1DT2H") as well. With SetDurationUnit(), a bare number ("30")
+// is read in that unit
 func (c *DynamicParams) GetStringAsTimeDuration(name string) (*time.Duration, error) {
 	if c.Mx != nil {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return nil, errors.New(ErrNotFound)
 	}
@@ -195,26 +245,27 @@ func (c *DynamicParams) GetStringAsTimeDuration(name string) (*time.Duration, er
 	if err != nil {
 		return nil, err
 	}
-	vd, err := time.ParseDuration(vs)
+	vd, err := parseDurationIn(vs, c.options().durationUnit)
 	if err != nil {
-		return nil, errors.New(ErrCnvFailed)
+		return nil, err
 	}
 	return &vd, nil
 }
 
-// if string has these values: 0, 1, true or false,
-// then this method converts them bool type and then returns
-// the value
+// if string is one of the words of the bool vocabulary (yes, no, on,
+// off, 1, 0, true, false ... in any case), then this method converts
+// it to bool type and then returns the value. A bool is returned as it
+// is. See SetBoolValues() to change the vocabulary
 func (c *DynamicParams) GetStringAsBool(name string) (bool, error) {
 	if c.Mx != nil {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return false, errors.New(ErrNotFound)
 	}
-	return convertNumericStrToBool(v)
+	return convertStrToBool(v, c.options().boolValues())
 }
 
 func (c *DynamicParams) GetAsInt32(name string) (int32, error) {
@@ -222,10 +273,14 @@ func (c *DynamicParams) GetAsInt32(name string) (int32, error) {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return 0, errors.New(ErrNotFound)
 	}
+	if c.options().lenient {
+		n, err := lenientInt(v, 32)
+		return int32(n), err
+	}
 	return convertToInt32(v)
 }
 
@@ -234,10 +289,13 @@ func (c *DynamicParams) GetAsInt64(name string) (int64, error) {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return 0, errors.New(ErrNotFound)
 	}
+	if c.options().lenient {
+		return lenientInt(v, 64)
+	}
 	return convertToInt64(v)
 }
 
@@ -246,10 +304,14 @@ func (c *DynamicParams) GetAsInt8(name string) (int8, error) {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return 0, errors.New(ErrNotFound)
 	}
+	if c.options().lenient {
+		n, err := lenientInt(v, 8)
+		return int8(n), err
+	}
 	return convertToInt8(v)
 }
 func (c *DynamicParams) GetAsInt16(name string) (int16, error) {
@@ -257,22 +319,40 @@ func (c *DynamicParams) GetAsInt16(name string) (int16, error) {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return 0, errors.New(ErrNotFound)
 	}
+	if c.options().lenient {
+		n, err := lenientInt(v, 16)
+		return int16(n), err
+	}
 	return convertToInt16(v)
 }
 
+// Returns the value if it is a time.Duration. With SetDurationUnit(),
+// an integer (e.g. the seconds of a JSON document) is accepted as well,
+// and counted in that unit
 func (c *DynamicParams) GetAsTimeDuration(name string) (*time.Duration, error) {
 	if c.Mx != nil {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return nil, errors.New(ErrNotFound)
 	}
+	if unit := c.options().durationUnit; unit != 0 {
+		if _, ok := v.(time.Duration); !ok {
+			if _, ok := v.(*time.Duration); !ok {
+				d, err := durationFromInt(v, unit)
+				if err != nil {
+					return nil, err
+				}
+				return &d, nil
+			}
+		}
+	}
 	return convertToTimeDuration(v)
 }
 
@@ -281,11 +361,48 @@ func (c *DynamicParams) GetAsBool(name string) (bool, error) {
 		c.Mx.RLock()
 		defer c.Mx.RUnlock()
 	}
-	v := c.source.Get(name)
+	v := c.get(name)
 	if v == nil {
 		return false, errors.New(ErrNotFound)
 	}
 	return convertToBool(v)
 }
 
+// returns a list of strings, such as the values of a repeatable
+// flag of SrcNameArgs, a list of strings in a JSON/YAML document,
+// or a delimited string ("a, b, 'c,d'"), see SetListOptions()
+func (c *DynamicParams) GetAsStringSlice(name string) ([]string, error) {
+	items, err := c.getList(name)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]string, 0, len(items))
+	for i, item := range items {
+		str, err := convertToString(item)
+		if err != nil {
+			return nil, listItemError(name, i, err)
+		}
+		list = append(list, str)
+	}
+	return list, nil
+}
 
+// returns a map of strings, such as the values of a map flag
+// of SrcNameArgs (--label=env=prod --label=team=core), a map of
+// strings in a JSON/YAML document, or a string of key=value pairs
+// ("env=prod,team=core"), see SetListOptions()
+func (c *DynamicParams) GetAsStringMap(name string) (map[string]string, error) {
+	entries, err := c.getMap(name)
+	if err != nil {
+		return nil, err
+	}
+	mp := make(map[string]string, len(entries))
+	for _, k := range sortedKeys(entries) {
+		str, err := convertToString(entries[k])
+		if err != nil {
+			return nil, mapEntryError(name, k, err)
+		}
+		mp[k] = str
+	}
+	return mp, nil
+}
@@ -1,6 +1,10 @@
 package dyanmic_params
 
-import "time"
+import (
+	"net"
+	"net/url"
+	"time"
+)
 
 // Use any function started with Q as a faster way of calling
 // methods. It suppress any error and returns the zero-value
@@ -110,3 +114,255 @@ func (d *DynamicParams) QGetStringAsTimeDuration(key string) *time.Duration {
 	}
 	return v
 }
+func (d *DynamicParams) QGetStringSlice(key string) []string {
+	v, err := d.GetAsStringSlice(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringMap(key string) map[string]string {
+	v, err := d.GetAsStringMap(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetUint(key string) uint {
+	v, err := d.GetAsUint(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetUint8(key string) uint8 {
+	v, err := d.GetAsUint8(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetUint16(key string) uint16 {
+	v, err := d.GetAsUint16(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetUint32(key string) uint32 {
+	v, err := d.GetAsUint32(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetUint64(key string) uint64 {
+	v, err := d.GetAsUint64(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetUintptr(key string) uintptr {
+	v, err := d.GetAsUintptr(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetFloat32(key string) float32 {
+	v, err := d.GetAsFloat32(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetFloat64(key string) float64 {
+	v, err := d.GetAsFloat64(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsUint(key string) uint {
+	v, err := d.GetStringAsUint(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsUint8(key string) uint8 {
+	v, err := d.GetStringAsUint8(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsUint16(key string) uint16 {
+	v, err := d.GetStringAsUint16(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsUint32(key string) uint32 {
+	v, err := d.GetStringAsUint32(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsUint64(key string) uint64 {
+	v, err := d.GetStringAsUint64(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsUintptr(key string) uintptr {
+	v, err := d.GetStringAsUintptr(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsFloat32(key string) float32 {
+	v, err := d.GetStringAsFloat32(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsFloat64(key string) float64 {
+	v, err := d.GetStringAsFloat64(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsInt8(key string) int8 {
+	v, err := d.GetStringAsInt8(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsInt16(key string) int16 {
+	v, err := d.GetStringAsInt16(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsInt32(key string) int32 {
+	v, err := d.GetStringAsInt32(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetStringAsInt64(key string) int64 {
+	v, err := d.GetStringAsInt64(key)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+func (d *DynamicParams) QGetIntSlice(key string) []int {
+	v, err := d.GetAsIntSlice(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetInt64Slice(key string) []int64 {
+	v, err := d.GetAsInt64Slice(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetFloat64Slice(key string) []float64 {
+	v, err := d.GetAsFloat64Slice(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetBoolSlice(key string) []bool {
+	v, err := d.GetAsBoolSlice(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetDurationSlice(key string) []time.Duration {
+	v, err := d.GetAsDurationSlice(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetIntMap(key string) map[string]int {
+	v, err := d.GetAsIntMap(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetTime(key string) time.Time {
+	v, err := d.GetAsTime(key)
+	if err != nil {
+		return time.Time{}
+	}
+	return v
+}
+func (d *DynamicParams) QGetDate(key string) time.Time {
+	v, err := d.GetAsDate(key)
+	if err != nil {
+		return time.Time{}
+	}
+	return v
+}
+func (d *DynamicParams) QGetLocation(key string) *time.Location {
+	v, err := d.GetAsLocation(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetURL(key string, schemes ...string) *url.URL {
+	v, err := d.GetAsURL(key, schemes...)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetIP(key string) net.IP {
+	v, err := d.GetAsIP(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetIPNet(key string) *net.IPNet {
+	v, err := d.GetAsIPNet(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetIPNetSlice(key string) []*net.IPNet {
+	v, err := d.GetAsIPNetSlice(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+func (d *DynamicParams) QGetTCPAddr(key string) *net.TCPAddr {
+	v, err := d.GetAsTCPAddr(key)
+	if err != nil {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,226 @@
+package dyanmic_params
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// the key which holds the type hints in the JSON
+// written by DynamicParams with JSONOptions.TypeHints
+const JSONTypesKey = "$types"
+
+// JSONOptions controls how DynamicParams is written as JSON
+type JSONOptions struct {
+	// writes nested objects, {"db": {"host": "x"}}, instead of flat
+	// keys, {"db.host": "x"}. A key which is also the prefix of other
+	// keys cannot be nested, and is kept flat
+	Nested bool
+
+	// adds the Go types of the values which do not survive a trip
+	// through JSON (ints, floats, time.Duration, time.Time, []byte)
+	// under JSONTypesKey, so that UnmarshalJSON() restores them
+	TypeHints bool
+}
+
+// Sets how MarshalJSON() writes the params
+func (c *DynamicParams) SetJSONOptions(opts JSONOptions) *DynamicParams {
+	return c.setOption(func(o *paramsOptions) {
+		o.json = opts
+	})
+}
+
+// Writes the params as a JSON object, with its keys sorted.
+// time.Duration values are written as strings ("1m30s"),
+// see SetJSONOptions() for nested output and type hints
+func (c *DynamicParams) MarshalJSON() ([]byte, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	if c.source == nil {
+		return []byte("{}"), nil
+	}
+	return encodeJSONParams(c.source.Iterate, c.options().json)
+}
+
+// Reads a JSON object, written by MarshalJSON() or not, and replaces
+// the params with its params: nested objects are flattened the same
+// way SrcNameJSON does, numbers are kept as json.Number, and values
+// listed under JSONTypesKey are converted back to their Go types.
+//
+// Like the UnmarshalJSON() of the built-in sources, which it calls,
+// the params already set are dropped. An instance without a source
+// (e.g. a zero value) gets a SrcNameInternal one, and a source which
+// does not implement json.Unmarshaler is an error
+func (c *DynamicParams) UnmarshalJSON(data []byte) error {
+	if c.Mx != nil {
+		c.Mx.Lock()
+		defer c.Mx.Unlock()
+	}
+	if c.source == nil {
+		c.source = NewSourceInternal()
+	}
+	u, ok := c.source.(json.Unmarshaler)
+	if !ok {
+		return errors.New("the source of the params does not implement json.Unmarshaler")
+	}
+	return u.UnmarshalJSON(data)
+}
+
+func encodeJSONParams(iterate func(fn ParamsIteratorFn), opts JSONOptions) ([]byte, error) {
+	flat := make(map[string]interface{}, 0)
+	types := make(map[string]string, 0)
+	iterate(func(k string, v interface{}) {
+		value, hint := jsonValue(v)
+		flat[k] = value
+		if hint != "" {
+			types[k] = hint
+		}
+	})
+	out := flat
+	if opts.Nested {
+		out = nestParams(flat)
+	}
+	if opts.TypeHints && len(types) > 0 {
+		out[JSONTypesKey] = types
+	}
+	return json.Marshal(out)
+}
+
+// returns the value to write, and the type hint it needs
+func jsonValue(v interface{}) (interface{}, string) {
+	switch val := v.(type) {
+	case time.Duration:
+		return val.String(), "duration"
+	case *time.Duration:
+		if val != nil {
+			return val.String(), "duration"
+		}
+	case time.Time:
+		return val, "time"
+	case []byte:
+		return val, "bytes"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return val, reflect.TypeOf(val).String()
+	}
+	return v, ""
+}
+
+// converts flat keys into nested objects, keys are processed in order
+// so that a key which is a value by itself keeps its place, and the
+// longer keys it conflicts with stay flat
+func nestParams(flat map[string]interface{}) map[string]interface{} {
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]interface{}, 0)
+	for _, k := range keys {
+		parts := strings.Split(k, KeyDelimiter)
+		node := out
+		for _, part := range parts[:len(parts)-1] {
+			child, exists := node[part]
+			if !exists {
+				m := make(map[string]interface{}, 0)
+				node[part] = m
+				node = m
+				continue
+			}
+			if m, ok := child.(map[string]interface{}); ok {
+				node = m
+				continue
+			}
+			node = nil
+			break
+		}
+		if node == nil {
+			out[k] = flat[k]
+		} else if _, exists := node[parts[len(parts)-1]]; exists {
+			out[k] = flat[k]
+		} else {
+			node[parts[len(parts)-1]] = flat[k]
+		}
+	}
+	return out
+}
+
+// reads a JSON object into flat params, applying the type hints
+func decodeJSONParams(data []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if err := expectJSONEnd(dec); err != nil {
+		return nil, err
+	}
+	types, _ := doc[JSONTypesKey].(map[string]interface{})
+	delete(doc, JSONTypesKey)
+
+	params := make(map[string]interface{}, 0)
+	flattenParams("", doc, params)
+	for k, hint := range types {
+		v, ok := params[k]
+		if !ok {
+			continue
+		}
+		h, _ := hint.(string)
+		converted, err := applyTypeHint(v, h)
+		if err != nil {
+			return nil, errors.New(k + ": cannot restore " + h + ": " + err.Error())
+		}
+		params[k] = converted
+	}
+	return params, nil
+}
+
+var hintTypes = map[string]reflect.Type{
+	"int":      reflect.TypeOf(int(0)),
+	"int8":     reflect.TypeOf(int8(0)),
+	"int16":    reflect.TypeOf(int16(0)),
+	"int32":    reflect.TypeOf(int32(0)),
+	"int64":    reflect.TypeOf(int64(0)),
+	"uint":     reflect.TypeOf(uint(0)),
+	"uint8":    reflect.TypeOf(uint8(0)),
+	"uint16":   reflect.TypeOf(uint16(0)),
+	"uint32":   reflect.TypeOf(uint32(0)),
+	"uint64":   reflect.TypeOf(uint64(0)),
+	"float32":  reflect.TypeOf(float32(0)),
+	"float64":  reflect.TypeOf(float64(0)),
+	"duration": durationType,
+}
+
+func applyTypeHint(v interface{}, hint string) (interface{}, error) {
+	switch hint {
+	case "time":
+		s, err := convertToString(v)
+		if err != nil {
+			return nil, err
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case "bytes":
+		s, err := convertToString(v)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.DecodeString(s)
+	}
+	typ, ok := hintTypes[hint]
+	if !ok {
+		return v, nil
+	}
+	dst := reflect.New(typ).Elem()
+	if err := assignValue(dst, v, &paramsOptions{}); err != nil {
+		return nil, err
+	}
+	return dst.Interface(), nil
+}
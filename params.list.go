@@ -0,0 +1,124 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Sets how the slice accessors split a string into a list
+func (c *DynamicParams) SetListOptions(opts ListOptions) *DynamicParams {
+	return c.setOption(func(o *paramsOptions) {
+		o.list = opts
+	})
+}
+
+// returns the items of the list stored under name, see splitList()
+func (c *DynamicParams) getList(name string) ([]interface{}, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return nil, errors.New(ErrNotFound)
+	}
+	return splitList(v, c.options().list)
+}
+
+// names the item of a list which failed to convert, e.g. "origins[2]"
+func listItemError(name string, i int, err error) error {
+	return &ParamError{Key: name + "[" + strconv.Itoa(i) + "]", Err: err}
+}
+
+// Returns a list of ints. The items can be numbers of any type (which
+// must fit in an int) or numeric strings, parsed like GetStringAsInt()
+func (c *DynamicParams) GetAsIntSlice(name string) ([]int, error) {
+	items, err := c.getList(name)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]int, 0, len(items))
+	for i, item := range items {
+		n, err := convertItemToInt(item, strconv.IntSize)
+		if err != nil {
+			return nil, listItemError(name, i, err)
+		}
+		list = append(list, int(n))
+	}
+	return list, nil
+}
+
+// Refer to GetAsIntSlice()
+func (c *DynamicParams) GetAsInt64Slice(name string) ([]int64, error) {
+	items, err := c.getList(name)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]int64, 0, len(items))
+	for i, item := range items {
+		n, err := convertItemToInt(item, 64)
+		if err != nil {
+			return nil, listItemError(name, i, err)
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+// Returns a list of floats, from numbers of any type or from
+// strings, parsed like GetStringAsFloat64()
+func (c *DynamicParams) GetAsFloat64Slice(name string) ([]float64, error) {
+	items, err := c.getList(name)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]float64, 0, len(items))
+	for i, item := range items {
+		f, err := convertItemToFloat(item, 64)
+		if err != nil {
+			return nil, listItemError(name, i, err)
+		}
+		list = append(list, f)
+	}
+	return list, nil
+}
+
+// Returns a list of bools, from bools or from strings,
+// parsed like GetStringAsBool()
+func (c *DynamicParams) GetAsBoolSlice(name string) ([]bool, error) {
+	items, err := c.getList(name)
+	if err != nil {
+		return nil, err
+	}
+	values := c.options().boolValues()
+	list := make([]bool, 0, len(items))
+	for i, item := range items {
+		b, err := convertStrToBool(item, values)
+		if err != nil {
+			return nil, listItemError(name, i, err)
+		}
+		list = append(list, b)
+	}
+	return list, nil
+}
+
+// Returns a list of durations, from time.Duration values or
+// from strings, parsed like GetStringAsTimeDuration(). With
+// SetDurationUnit(), integers and bare numbers are read in that unit
+func (c *DynamicParams) GetAsDurationSlice(name string) ([]time.Duration, error) {
+	items, err := c.getList(name)
+	if err != nil {
+		return nil, err
+	}
+	unit := c.lockedOptions().durationUnit
+	list := make([]time.Duration, 0, len(items))
+	for i, item := range items {
+		d, err := convertItemToDuration(item, unit)
+		if err != nil {
+			return nil, listItemError(name, i, err)
+		}
+		list = append(list, d)
+	}
+	return list, nil
+}
@@ -0,0 +1,109 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// returns the entries of the map stored under name, see splitMap().
+// As JSON, YAML and env sources flatten nested objects, when name is
+// not a key, the keys under it (name.cpu, name.mem) are the entries
+func (c *DynamicParams) getMap(name string) (map[string]interface{}, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		if entries := c.entriesUnder(name); len(entries) > 0 {
+			return entries, nil
+		}
+		return nil, errors.New(ErrNotFound)
+	}
+	return splitMap(name, v, c.options().list)
+}
+
+// returns the params whose keys start with name and KeyDelimiter,
+// with this prefix stripped
+func (c *DynamicParams) entriesUnder(name string) map[string]interface{} {
+	prefix := name + KeyDelimiter
+	var entries map[string]interface{}
+	c.source.Iterate(func(k string, v interface{}) {
+		if len(k) > len(prefix) && strings.HasPrefix(k, prefix) {
+			if entries == nil {
+				entries = make(map[string]interface{}, 0)
+			}
+			entries[k[len(prefix):]] = v
+		}
+	})
+	return entries
+}
+
+// names the entry of a map which failed to convert, e.g. "labels.env"
+func mapEntryError(name, key string, err error) error {
+	return &ParamError{Key: joinKey(name, key), Err: err}
+}
+
+// Returns a map of ints, from a map whose values are numbers of any
+// type (which must fit in an int) or numeric strings, or from a string
+// of key=value pairs ("web=3,worker=5")
+func (c *DynamicParams) GetAsIntMap(name string) (map[string]int, error) {
+	entries, err := c.getMap(name)
+	if err != nil {
+		return nil, err
+	}
+	mp := make(map[string]int, len(entries))
+	for _, k := range sortedKeys(entries) {
+		n, err := convertItemToInt(entries[k], strconv.IntSize)
+		if err != nil {
+			return nil, mapEntryError(name, k, err)
+		}
+		mp[k] = int(n)
+	}
+	return mp, nil
+}
+
+// Fills out, a pointer to a map with string keys, with the entries of
+// the map stored under name (see GetAsStringMap() for the accepted
+// values). The values are converted the way Bind() converts fields, so
+// any type Bind() supports can be used:
+//
+//	var timeouts map[string]time.Duration
+//	err := p.GetAsMapOf("timeouts", &timeouts) // read=5s,write=10s
+//
+// The entries are added to the map if it is not nil, and only once
+// all of them are converted: on error, the map is left as it was
+func (c *DynamicParams) GetAsMapOf(name string, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Map ||
+		rv.Elem().Type().Key().Kind() != reflect.String {
+		return errors.New("GetAsMapOf expects a non-nil pointer to a map with string keys")
+	}
+	entries, err := c.getMap(name)
+	if err != nil {
+		return err
+	}
+	// converted aside, so that a failing entry leaves out untouched
+	mp := rv.Elem()
+	typ := mp.Type()
+	converted := reflect.MakeMapWithSize(typ, len(entries))
+	opts := c.options()
+	for _, k := range sortedKeys(entries) {
+		val := reflect.New(typ.Elem()).Elem()
+		if err := assignValue(val, entries[k], opts); err != nil {
+			return mapEntryError(name, k, err)
+		}
+		converted.SetMapIndex(reflect.ValueOf(k).Convert(typ.Key()), val)
+	}
+	if mp.IsNil() {
+		mp.Set(converted)
+		return nil
+	}
+	iter := converted.MapRange()
+	for iter.Next() {
+		mp.SetMapIndex(iter.Key(), iter.Value())
+	}
+	return nil
+}
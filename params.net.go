@@ -0,0 +1,98 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"net"
+	"net/url"
+)
+
+// Returns the value as an absolute URL. With schemes, the URL must use
+// one of them: p.GetAsURL("endpoint", "https") fails with
+// ErrSchemeNotAllowed for http://..., and so does a URL without scheme
+func (c *DynamicParams) GetAsURL(name string, schemes ...string) (*url.URL, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return nil, errors.New(ErrNotFound)
+	}
+	return convertToURL(v, schemes)
+}
+
+// Returns the value as an IPv4 or IPv6 address
+func (c *DynamicParams) GetAsIP(name string) (net.IP, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return nil, errors.New(ErrNotFound)
+	}
+	return convertToIP(v)
+}
+
+// Returns the value as a network, from a CIDR ("10.0.0.0/8"),
+// or a single address ("10.0.0.1", read as 10.0.0.1/32)
+func (c *DynamicParams) GetAsIPNet(name string) (*net.IPNet, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return nil, errors.New(ErrNotFound)
+	}
+	return convertToIPNet(v)
+}
+
+// Returns a list of networks, such as an allow-list, from a list or
+// a delimited string ("10.0.0.0/8, 192.168.1.10"), each item is read
+// like GetAsIPNet()
+func (c *DynamicParams) GetAsIPNetSlice(name string) ([]*net.IPNet, error) {
+	items, err := c.getList(name)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*net.IPNet, 0, len(items))
+	for i, item := range items {
+		ipNet, err := convertToIPNet(item)
+		if err != nil {
+			return nil, listItemError(name, i, err)
+		}
+		list = append(list, ipNet)
+	}
+	return list, nil
+}
+
+// Splits "host:port" ("[::1]:8080" for IPv6), the port must be a number
+// from 0 to 65535, otherwise it fails with ErrPortRange. Nothing is
+// resolved, so host can be a name or an address
+func (c *DynamicParams) GetAsHostPort(name string) (string, int, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return "", 0, errors.New(ErrNotFound)
+	}
+	return splitHostPort(v)
+}
+
+// Returns the value as a TCP address, from "ip:port" (or ":port"),
+// without resolving names: a host which is not an IP address fails
+// with ErrNotIPAddress, use GetAsHostPort() for names
+func (c *DynamicParams) GetAsTCPAddr(name string) (*net.TCPAddr, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return nil, errors.New(ErrNotFound)
+	}
+	return convertToTCPAddr(v)
+}
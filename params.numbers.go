@@ -0,0 +1,295 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Returns the value as an unsigned integer, it fails with ErrOverflow
+// when a number decoded from JSON does not fit in the type
+func (c *DynamicParams) GetAsUint(name string) (uint, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	if c.options().lenient {
+		n, err := lenientUint(v, strconv.IntSize)
+		return uint(n), err
+	}
+	return convertToUint(v)
+}
+
+func (c *DynamicParams) GetAsUint8(name string) (uint8, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	if c.options().lenient {
+		n, err := lenientUint(v, 8)
+		return uint8(n), err
+	}
+	return convertToUint8(v)
+}
+
+func (c *DynamicParams) GetAsUint16(name string) (uint16, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	if c.options().lenient {
+		n, err := lenientUint(v, 16)
+		return uint16(n), err
+	}
+	return convertToUint16(v)
+}
+
+func (c *DynamicParams) GetAsUint32(name string) (uint32, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	if c.options().lenient {
+		n, err := lenientUint(v, 32)
+		return uint32(n), err
+	}
+	return convertToUint32(v)
+}
+
+func (c *DynamicParams) GetAsUint64(name string) (uint64, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	if c.options().lenient {
+		return lenientUint(v, 64)
+	}
+	return convertToUint64(v)
+}
+
+func (c *DynamicParams) GetAsUintptr(name string) (uintptr, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	if c.options().lenient {
+		n, err := lenientUint(v, uintptrSize)
+		return uintptr(n), err
+	}
+	return convertToUintptr(v)
+}
+
+// Returns the value as a float, NaN and infinities fail with ErrNotFinite
+func (c *DynamicParams) GetAsFloat32(name string) (float32, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	if c.options().lenient {
+		n, err := lenientFloat(v, 32)
+		return float32(n), err
+	}
+	return convertToFloat32(v)
+}
+
+func (c *DynamicParams) GetAsFloat64(name string) (float64, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	if c.options().lenient {
+		return lenientFloat(v, 64)
+	}
+	return convertToFloat64(v)
+}
+
+// Refer to GetStringAsInt()
+func (c *DynamicParams) GetStringAsInt8(name string) (int8, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	n, err := convertNumericStrToInt(v, 8)
+	return int8(n), err
+}
+
+func (c *DynamicParams) GetStringAsInt16(name string) (int16, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	n, err := convertNumericStrToInt(v, 16)
+	return int16(n), err
+}
+
+func (c *DynamicParams) GetStringAsInt32(name string) (int32, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	n, err := convertNumericStrToInt(v, 32)
+	return int32(n), err
+}
+
+func (c *DynamicParams) GetStringAsInt64(name string) (int64, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	return convertNumericStrToInt(v, 64)
+}
+
+// Parses a numeric string, such as "8080" or "0xFF", as an unsigned
+// integer, refer to GetStringAsInt(). A negative number is a conversion
+// failure, and a number larger than the type fails with ErrOverflow
+func (c *DynamicParams) GetStringAsUint(name string) (uint, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	n, err := convertNumericStrToUint(v, strconv.IntSize)
+	return uint(n), err
+}
+
+func (c *DynamicParams) GetStringAsUint8(name string) (uint8, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	n, err := convertNumericStrToUint(v, 8)
+	return uint8(n), err
+}
+
+func (c *DynamicParams) GetStringAsUint16(name string) (uint16, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	n, err := convertNumericStrToUint(v, 16)
+	return uint16(n), err
+}
+
+func (c *DynamicParams) GetStringAsUint32(name string) (uint32, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	n, err := convertNumericStrToUint(v, 32)
+	return uint32(n), err
+}
+
+func (c *DynamicParams) GetStringAsUint64(name string) (uint64, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	return convertNumericStrToUint(v, 64)
+}
+
+func (c *DynamicParams) GetStringAsUintptr(name string) (uintptr, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	n, err := convertNumericStrToUint(v, uintptrSize)
+	return uintptr(n), err
+}
+
+// Parses a string, such as "0.25" or "1e-3", as a float. "NaN" and "Inf"
+// fail with ErrNotFinite, and a number too large for the type ("1e400")
+// fails with ErrOverflow
+func (c *DynamicParams) GetStringAsFloat32(name string) (float32, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	f, err := convertNumericStrToFloat(v, 32)
+	return float32(f), err
+}
+
+func (c *DynamicParams) GetStringAsFloat64(name string) (float64, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return 0, errors.New(ErrNotFound)
+	}
+	return convertNumericStrToFloat(v, 64)
+}
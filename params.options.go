@@ -0,0 +1,107 @@
+package dyanmic_params
+
+import "time"
+
+// the settings of an instance of DynamicParams, the views
+// returned by Sub() and Command() share them with their parent
+type paramsOptions struct {
+	json    JSONOptions
+	lenient bool
+	bools   *BoolValues
+	list    ListOptions
+
+	durationUnit time.Duration
+	time         TimeOptions
+}
+
+// returns the settings of c, for an instance which was not
+// created by a constructor (e.g. a zero value), the defaults
+func (c *DynamicParams) options() *paramsOptions {
+	if c.opts == nil {
+		return &paramsOptions{}
+	}
+	return c.opts
+}
+
+// returns a copy of the settings of c, read under its lock, for
+// the methods which do not hold it while converting
+func (c *DynamicParams) lockedOptions() paramsOptions {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	return *c.options()
+}
+
+// returns the vocabulary set by SetBoolValues(), or the default one
+func (o *paramsOptions) boolValues() BoolValues {
+	if o.bools == nil {
+		return defaultBoolValues
+	}
+	return *o.bools
+}
+
+// changes the settings of c, under its lock
+func (c *DynamicParams) setOption(fn func(o *paramsOptions)) *DynamicParams {
+	if c.Mx != nil {
+		c.Mx.Lock()
+		defer c.Mx.Unlock()
+	}
+	if c.opts == nil {
+		c.opts = &paramsOptions{}
+	}
+	fn(c.opts)
+	return c
+}
+
+// With lenient numbers, the numeric GetAs* methods (GetAsInt(),
+// GetAsUint64(), GetAsFloat32() ...) convert any numeric value to the
+// requested type, e.g. an int64, a uint16 or a float64 (as set by
+// Set() or SetFromStruct()) read by GetAsInt(). A value out of the
+// range of the type fails with ErrOverflow, and one which would lose
+// its fractional part or precision with ErrTruncated. Without it, the
+// value must have the exact type, or be a json.Number
+func (c *DynamicParams) SetLenientNumbers(lenient bool) *DynamicParams {
+	return c.setOption(func(o *paramsOptions) {
+		o.lenient = lenient
+	})
+}
+
+// Returns a view of c with lenient numbers, for a single read:
+// p.Lenient().GetAsInt("port"). The view shares the storage and
+// the lock of c, see SetLenientNumbers()
+func (c *DynamicParams) Lenient() *DynamicParams {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	opts := *c.options()
+	opts.lenient = true
+	return &DynamicParams{
+		Mx:     c.Mx,
+		source: c.source,
+		opts:   &opts,
+	}
+}
+
+// Sets the words GetStringAsBool() reads as true and false, replacing
+// the default ones. To extend them, start from DefaultBoolValues():
+//
+//	bools := dp.DefaultBoolValues()
+//	bools.True = append(bools.True, "si")
+//	p.SetBoolValues(bools)
+func (c *DynamicParams) SetBoolValues(values BoolValues) *DynamicParams {
+	return c.setOption(func(o *paramsOptions) {
+		o.bools = &values
+	})
+}
+
+// Sets the unit of the durations given as integers, e.g. time.Second
+// for a JSON document with {"ttl": 30}: GetAsTimeDuration() then
+// accepts integers of any type, and GetStringAsTimeDuration() bare
+// numbers ("30"). Zero, the default, accepts neither
+func (c *DynamicParams) SetDurationUnit(unit time.Duration) *DynamicParams {
+	return c.setOption(func(o *paramsOptions) {
+		o.durationUnit = unit
+	})
+}
@@ -0,0 +1,143 @@
+package dyanmic_params
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// one step of a path, either a key (or struct field) or a list index
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// returns the value of name and reports if it exists. When name is not
+// a key by itself, it is read as a path into a compound value, starting
+// from the longest prefix of name which is a key: "db.primary.host" can
+// be the key db.primary.host, the key "host" of the map db.primary, or
+// primary.host inside the map or struct stored under db. Lists are
+// indexed with brackets, as in "servers[2].port"
+func (c *DynamicParams) lookup(name string) (interface{}, bool) {
+	if c.source.Has(name) {
+		return c.source.Get(name), true
+	}
+	for i := len(name) - 1; i > 0; i-- {
+		if name[i] != '.' && name[i] != '[' {
+			continue
+		}
+		prefix := name[:i]
+		if !c.source.Has(prefix) {
+			continue
+		}
+		segments, ok := parsePath(name[i:])
+		if !ok {
+			return nil, false
+		}
+		return walkPath(c.source.Get(prefix), segments)
+	}
+	return nil, false
+}
+
+// path-aware replacement of source.Get(), which keeps its
+// result when name is neither a key nor a path
+func (c *DynamicParams) get(name string) interface{} {
+	if v, ok := c.lookup(name); ok {
+		return v
+	}
+	return c.source.Get(name)
+}
+
+// parses the rest of a path, such as ".primary.host" or "[2].port"
+func parsePath(path string) ([]pathSegment, bool) {
+	var segments []pathSegment
+	for path != "" {
+		if path[0] == '.' {
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			if end == 0 {
+				return nil, false
+			}
+			segments = append(segments, pathSegment{key: path[:end]})
+			path = path[end:]
+		} else if path[0] == '[' {
+			end := strings.IndexByte(path, ']')
+			if end < 2 {
+				return nil, false
+			}
+			key := path[1:end]
+			seg := pathSegment{key: key}
+			if n, err := strconv.Atoi(key); err == nil {
+				seg.index, seg.isIndex = n, true
+			}
+			segments = append(segments, seg)
+			path = path[end+1:]
+		} else {
+			return nil, false
+		}
+	}
+	return segments, true
+}
+
+// walks the segments through maps, slices, arrays
+// and exported struct fields
+func walkPath(val interface{}, segments []pathSegment) (interface{}, bool) {
+	rv := reflect.ValueOf(val)
+	for _, seg := range segments {
+		for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+			if rv.IsNil() {
+				return nil, false
+			}
+			rv = rv.Elem()
+		}
+		switch rv.Kind() {
+		case reflect.Map:
+			key := reflect.ValueOf(seg.key)
+			if rv.Type().Key().Kind() == reflect.String {
+				key = key.Convert(rv.Type().Key())
+			} else if rv.Type().Key().Kind() != reflect.Interface {
+				return nil, false
+			}
+			rv = rv.MapIndex(key)
+			if !rv.IsValid() {
+				return nil, false
+			}
+		case reflect.Slice, reflect.Array:
+			if !seg.isIndex || seg.index < 0 || seg.index >= rv.Len() {
+				return nil, false
+			}
+			rv = rv.Index(seg.index)
+		case reflect.Struct:
+			rv = structFieldByKey(rv, seg.key)
+			if !rv.IsValid() {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	if !rv.IsValid() || !rv.CanInterface() {
+		return nil, false
+	}
+	return rv.Interface(), true
+}
+
+// finds an exported field by its name, its param tag,
+// or the kebab case of its name
+func structFieldByKey(rv reflect.Value, key string) reflect.Value {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		if field.Name == key || field.Tag.Get(TagParam) == key || kebabCase(field.Name) == key {
+			return rv.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
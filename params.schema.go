@@ -0,0 +1,275 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+const (
+	ErrTypeMismatch    = "type mismatch"
+	ErrBelowMin        = "value is less than the minimum"
+	ErrAboveMax        = "value is more than the maximum"
+	ErrPatternMismatch = "value does not match the pattern"
+	ErrNotInEnum       = "value is not one of the allowed values"
+)
+
+// ParamType is the type a param is expected to have, a value
+// of another type is accepted if it can be converted, e.g. the
+// string "8080" given by args is a valid TypeInt
+type ParamType string
+
+const (
+	TypeAny         ParamType = ""
+	TypeString      ParamType = "string"
+	TypeBool        ParamType = "bool"
+	TypeInt         ParamType = "int"
+	TypeInt64       ParamType = "int64"
+	TypeUint        ParamType = "uint"
+	TypeFloat       ParamType = "float"
+	TypeDuration    ParamType = "duration"
+	TypeStringSlice ParamType = "[]string"
+	TypeIntSlice    ParamType = "[]int"
+	TypeStringMap   ParamType = "map[string]string"
+	TypeURL         ParamType = "url"
+	TypeIP          ParamType = "ip"
+	TypeCIDR        ParamType = "cidr"
+	TypeTCPAddr     ParamType = "tcp-addr"
+)
+
+var paramTypes = map[ParamType]reflect.Type{
+	TypeString:      reflect.TypeOf(""),
+	TypeBool:        reflect.TypeOf(false),
+	TypeInt:         reflect.TypeOf(0),
+	TypeInt64:       reflect.TypeOf(int64(0)),
+	TypeUint:        reflect.TypeOf(uint(0)),
+	TypeFloat:       reflect.TypeOf(float64(0)),
+	TypeDuration:    reflect.TypeOf(time.Duration(0)),
+	TypeStringSlice: reflect.TypeOf([]string{}),
+	TypeIntSlice:    reflect.TypeOf([]int{}),
+	TypeStringMap:   reflect.TypeOf(map[string]string{}),
+	TypeURL:         reflect.TypeOf(&url.URL{}),
+	TypeIP:          reflect.TypeOf(net.IP{}),
+	TypeCIDR:        reflect.TypeOf(&net.IPNet{}),
+	TypeTCPAddr:     reflect.TypeOf(&net.TCPAddr{}),
+}
+
+// ParamSpec declares a param and the constraints its value must meet
+type ParamSpec struct {
+	Key         string
+	Type        ParamType
+	Default     interface{}
+	Description string
+
+	// a missing required param is an error,
+	// unless it has a Default
+	Required bool
+
+	// bounds of a number (durations in nanoseconds), or of the
+	// length of a string, list or map. Use Bound() to set them
+	Min *float64
+	Max *float64
+
+	// a regex the value (as a string) must match
+	Pattern string
+
+	// the allowed values, converted to Type before comparing
+	Enum []interface{}
+
+	// a custom check, called with the value converted to Type
+	Validate func(value interface{}) error
+
+	// Pattern, compiled when the spec is added to a Schema
+	pattern *regexp.Regexp
+}
+
+// Returns a pointer to v, to be used for ParamSpec.Min and ParamSpec.Max
+func Bound(v float64) *float64 {
+	return &v
+}
+
+// Schema is an ordered list of param declarations
+type Schema struct {
+	specs []*ParamSpec
+	errs  ParamErrors
+}
+
+// Creates a schema from specs, an invalid spec (a Pattern which does
+// not compile, or a Default which breaks the constraints of its spec)
+// is kept and reported by Err() and by Validate()
+func NewSchema(specs ...*ParamSpec) *Schema {
+	s := &Schema{}
+	for _, spec := range specs {
+		s.Add(spec)
+	}
+	return s
+}
+
+// Like NewSchema(), but returns the invalid specs as ParamErrors
+func NewSchemaE(specs ...*ParamSpec) (*Schema, error) {
+	s := NewSchema(specs...)
+	return s, s.Err()
+}
+
+// Adds spec to the schema, compiling its Pattern and checking its Default
+func (s *Schema) Add(spec *ParamSpec) *Schema {
+	s.specs = append(s.specs, spec)
+	if err := spec.compile(); err != nil {
+		s.errs = append(s.errs, &ParamError{Key: spec.Key, Err: err})
+	}
+	return s
+}
+
+// Returns the specs which are invalid as ParamErrors, or nil
+func (s *Schema) Err() error {
+	if len(s.errs) > 0 {
+		return s.errs
+	}
+	return nil
+}
+
+// Returns the declarations, in their order
+func (s *Schema) Specs() []*ParamSpec {
+	return s.specs
+}
+
+// Checks the params against schema, whatever the source is, and returns
+// every violation at once as ParamErrors, so that misconfiguration can be
+// reported at startup instead of when a param is first read
+func (c *DynamicParams) Validate(schema *Schema) error {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	errs := append(ParamErrors(nil), schema.errs...)
+	opts := c.options()
+	for _, spec := range schema.specs {
+		v, ok := c.lookup(spec.Key)
+		if !ok {
+			if spec.Required && spec.Default == nil {
+				errs = append(errs, &ParamError{Key: spec.Key, Err: errors.New(ErrRequired)})
+			}
+			continue
+		}
+		if err := spec.check(v, opts); err != nil {
+			errs = append(errs, &ParamError{Key: spec.Key, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Sets the Default of each declared param which is missing
+func (c *DynamicParams) ApplyDefaults(schema *Schema) *DynamicParams {
+	if c.Mx != nil {
+		c.Mx.Lock()
+		defer c.Mx.Unlock()
+	}
+	for _, spec := range schema.specs {
+		if spec.Default == nil {
+			continue
+		}
+		if _, ok := c.lookup(spec.Key); !ok {
+			c.source.Add(spec.Key, spec.Default)
+		}
+	}
+	return c
+}
+
+// checks that the Type is known, compiles the Pattern,
+// and checks that the Default meets the spec
+func (spec *ParamSpec) compile() error {
+	spec.pattern = nil
+	if _, ok := paramTypes[spec.Type]; !ok && spec.Type != TypeAny {
+		return fmt.Errorf("unknown type %q", spec.Type)
+	}
+	if spec.Pattern != "" {
+		rg, err := regexp.Compile(spec.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %v", err)
+		}
+		spec.pattern = rg
+	}
+	if spec.Default != nil {
+		if err := spec.check(spec.Default, &paramsOptions{}); err != nil {
+			return fmt.Errorf("invalid default: %v", err)
+		}
+	}
+	return nil
+}
+
+// converts v to the declared type, with the settings of the
+// instance, and checks the constraints
+func (spec *ParamSpec) check(v interface{}, opts *paramsOptions) error {
+	typed, err := spec.convert(v, opts)
+	if err != nil {
+		return errors.New(ErrTypeMismatch + ", expected " + string(spec.Type))
+	}
+	if spec.Min != nil || spec.Max != nil {
+		if n, ok := measure(typed); ok {
+			if spec.Min != nil && n < *spec.Min {
+				return fmt.Errorf("%s %v", ErrBelowMin, *spec.Min)
+			}
+			if spec.Max != nil && n > *spec.Max {
+				return fmt.Errorf("%s %v", ErrAboveMax, *spec.Max)
+			}
+		}
+	}
+	if spec.pattern != nil {
+		if !spec.pattern.MatchString(fmt.Sprint(typed)) {
+			return fmt.Errorf("%s %s", ErrPatternMismatch, spec.Pattern)
+		}
+	}
+	if len(spec.Enum) > 0 && !spec.inEnum(typed, opts) {
+		return fmt.Errorf("%s %v", ErrNotInEnum, spec.Enum)
+	}
+	if spec.Validate != nil {
+		return spec.Validate(typed)
+	}
+	return nil
+}
+
+func (spec *ParamSpec) convert(v interface{}, opts *paramsOptions) (interface{}, error) {
+	typ, ok := paramTypes[spec.Type]
+	if !ok {
+		return v, nil
+	}
+	dst := reflect.New(typ).Elem()
+	if err := assignValue(dst, v, opts); err != nil {
+		return nil, err
+	}
+	return dst.Interface(), nil
+}
+
+func (spec *ParamSpec) inEnum(typed interface{}, opts *paramsOptions) bool {
+	for _, allowed := range spec.Enum {
+		a, err := spec.convert(allowed, opts)
+		if err == nil && reflect.DeepEqual(a, typed) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the number to compare with Min and Max: a number
+// itself, or the length of a string, list or map
+func measure(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return float64(rv.Len()), true
+	}
+	return 0, false
+}
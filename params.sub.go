@@ -0,0 +1,190 @@
+package dyanmic_params
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// separators accepted between the prefix of a Sub() view and the
+// rest of a key, the first one is used when a view sets a param
+var subSeparators = []string{KeyDelimiter, "-"}
+
+// Returns a view of the params whose keys start with prefix, with
+// the prefix stripped: after Sub("header"), the key header-origin (or
+// header.origin) is read as origin. Get, Has, Scan, Iterate, Count and
+// all GetAs* methods work on the keys under the prefix only.
+//
+// The view shares the storage and the lock of c, and Set() on it adds
+// the param to c, joining the prefix and the name with KeyDelimiter.
+// If prefix ends with a separator ("header-"), it is matched as it is
+func (c *DynamicParams) Sub(prefix string) *DynamicParams {
+	return c.sub(prefix, false)
+}
+
+// Like Sub(), but Set() on the returned view is ignored
+func (c *DynamicParams) SubReadOnly(prefix string) *DynamicParams {
+	return c.sub(prefix, true)
+}
+
+func (c *DynamicParams) sub(prefix string, readOnly bool) *DynamicParams {
+	var prefixes []string
+	for _, sep := range subSeparators {
+		if strings.HasSuffix(prefix, sep) {
+			prefixes = []string{prefix}
+			break
+		}
+		prefixes = append(prefixes, prefix+sep)
+	}
+	return &DynamicParams{
+		Mx:   c.Mx,
+		opts: c.opts,
+		source: &subSource{
+			parent:   c,
+			prefixes: prefixes,
+			readOnly: readOnly,
+		},
+	}
+}
+
+// subSource is the ParamsSource behind the views returned by Sub(),
+// it does not lock, as the view shares the lock of its parent
+type subSource struct {
+	parent   *DynamicParams
+	prefixes []string
+	readOnly bool
+}
+
+func (s *subSource) Add(name string, value interface{}) ParamsSource {
+	if !s.readOnly {
+		s.parent.source.Add(s.prefixes[0]+name, value)
+	}
+	return s
+}
+
+func (s *subSource) Get(name string) interface{} {
+	for _, prefix := range s.prefixes {
+		if v, ok := s.parent.lookup(prefix + name); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *subSource) Has(name string) bool {
+	for _, prefix := range s.prefixes {
+		if _, ok := s.parent.lookup(prefix + name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *subSource) Scan(regex string) map[string]interface{} {
+	rg, err := regexp.Compile(regex)
+	if err != nil {
+		return nil
+	}
+	var mp map[string]interface{}
+	s.Iterate(func(k string, v interface{}) {
+		if rg.MatchString(k) {
+			if mp == nil {
+				mp = make(map[string]interface{}, 0)
+			}
+			mp[k] = v
+		}
+	})
+	return mp
+}
+
+// a key spelled with several separators (header.x and header-x) is
+// yielded once, with the value Get() returns for it. The entries of a
+// map set under the prefix itself (Set("db", map[string]interface{}
+// {...})) are yielded too, as Get() finds them by path; a flat key
+// takes precedence over the same entry of the map
+func (s *subSource) Iterate(fn func(k string, v interface{})) {
+	type entry struct {
+		name  string
+		value interface{}
+		rank  int
+	}
+	var entries []entry
+	seen := make(map[string]int, 0)
+	s.parent.source.Iterate(func(k string, v interface{}) {
+		name, rank, ok := s.strip(k)
+		if !ok {
+			return
+		}
+		if i, exists := seen[name]; exists {
+			if rank < entries[i].rank {
+				entries[i] = entry{name, v, rank}
+			}
+			return
+		}
+		seen[name] = len(entries)
+		entries = append(entries, entry{name, v, rank})
+	})
+	for i, prefix := range s.prefixes {
+		if !strings.HasSuffix(prefix, KeyDelimiter) {
+			continue
+		}
+		root, ok := s.parent.lookup(strings.TrimSuffix(prefix, KeyDelimiter))
+		if !ok {
+			continue
+		}
+		rank := len(s.prefixes) + i
+		walkMapEntries("", root, func(name string, v interface{}) {
+			if _, exists := seen[name]; exists {
+				return
+			}
+			seen[name] = len(entries)
+			entries = append(entries, entry{name, v, rank})
+		})
+	}
+	for _, e := range entries {
+		fn(e.name, e.value)
+	}
+}
+
+func (s *subSource) Count() int64 {
+	var cnt int64
+	s.Iterate(func(k string, v interface{}) {
+		cnt++
+	})
+	return cnt
+}
+
+// removes the prefix from key, and reports if key is under the prefix
+// and the rank of the prefix, a lower rank takes precedence
+func (s *subSource) strip(key string) (string, int, bool) {
+	for i, prefix := range s.prefixes {
+		if len(key) > len(prefix) && strings.HasPrefix(key, prefix) {
+			return key[len(prefix):], i, true
+		}
+	}
+	return "", 0, false
+}
+
+// calls fn for each leaf of the map val, its nested maps joined with
+// KeyDelimiter, an empty nested map being a leaf by itself. A value
+// which is not a map has no entries
+func walkMapEntries(prefix string, val interface{}, fn func(k string, v interface{})) {
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Map || (prefix != "" && rv.Len() == 0) {
+		if prefix != "" {
+			fn(prefix, val)
+		}
+		return
+	}
+	iter := rv.MapRange()
+	for iter.Next() {
+		walkMapEntries(joinKey(prefix, fmt.Sprint(iter.Key().Interface())), iter.Value().Interface(), fn)
+	}
+}
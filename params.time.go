@@ -0,0 +1,63 @@
+package dyanmic_params
+
+import (
+	"errors"
+	"time"
+)
+
+// Sets how GetAsTime() and GetAsDate() read times
+func (c *DynamicParams) SetTimeOptions(opts TimeOptions) *DynamicParams {
+	return c.setOption(func(o *paramsOptions) {
+		o.time = opts
+	})
+}
+
+// Returns the value as a time.Time: a time.Time as it is, a string
+// in RFC 3339 ("2024-03-01T02:00:00Z") or a date ("2024-03-01"), or
+// a number of seconds since the Unix epoch. See SetTimeOptions() for
+// other layouts, the location of dates and milliseconds
+func (c *DynamicParams) GetAsTime(name string) (time.Time, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return time.Time{}, errors.New(ErrNotFound)
+	}
+	return convertToTime(v, c.options().time)
+}
+
+// Returns the calendar date of the value, read like GetAsTime(), as
+// midnight in the location of TimeOptions (UTC by default). A time
+// is converted to this location before its date is taken
+func (c *DynamicParams) GetAsDate(name string) (time.Time, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return time.Time{}, errors.New(ErrNotFound)
+	}
+	opts := c.options().time
+	t, err := convertToTime(v, opts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return dateOf(t, opts.location()), nil
+}
+
+// Returns the value as a *time.Location, from the name of
+// a zone ("Europe/Berlin", "UTC", "Local")
+func (c *DynamicParams) GetAsLocation(name string) (*time.Location, error) {
+	if c.Mx != nil {
+		c.Mx.RLock()
+		defer c.Mx.RUnlock()
+	}
+	v := c.get(name)
+	if v == nil {
+		return nil, errors.New(ErrNotFound)
+	}
+	return convertToLocation(v)
+}
@@ -3,43 +3,135 @@ package dyanmic_params
 import (
 	"log"
 	"regexp"
-	"strings"
 )
 
 const SrcNameArgs = "source.args"
 
 type argsParamCollection map[string]interface{}
 
+// SourceArgs holds the flags of the command line as params, and keeps
+// the positional arguments and the selected subcommand aside. A
+// subcommand is a SourceArgs too, which sees the params of its
+// parent unless it has a flag with the same name
 type SourceArgs struct {
-	storage argsParamCollection
+	storage    argsParamCollection
+	positional []string
+	command    string
+	parent     *SourceArgs
+	sub        *SourceArgs
 }
 
-// args must be in this format: --key=value
-func NewSourceArgs(args interface{}) *SourceArgs {
+func init() {
+	RegisterSource(SrcNameArgs, func(vars ...interface{}) (ParamsSource, error) {
+		if len(vars) == 0 {
+			return nil, newSourceError("", ErrMissingSourceVars, "SourceArgs must have a args collection passed to NewSource()")
+		}
+		var opts []*ArgsOptions
+		if len(vars) > 1 {
+			o, ok := vars[1].(*ArgsOptions)
+			if !ok {
+				return nil, newSourceError("", ErrInvalidSourceVars, "SourceArgs accepts only an *ArgsOptions after the args collection")
+			}
+			opts = append(opts, o)
+		}
+		src, err := NewSourceArgsE(vars[0], opts...)
+		if err != nil {
+			return nil, err
+		}
+		return src, nil
+	})
+}
+
+// args are parsed in GNU style: --key=value, --key value (for the
+// flags declared in ArgsOptions.ValueFlags), bare --flag, --no-flag,
+// and short -v or -abc flags, with -- ending the list of flags.
+// opts is optional and declares aliases and flags which take a value
+func NewSourceArgs(args interface{}, opts ...*ArgsOptions) *SourceArgs {
+	src, err := NewSourceArgsE(args, opts...)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	return src
+}
+
+// Like NewSourceArgs(), but returns a *SourceError if args is
+// not a []string, or, in strict mode, if an argument is malformed
+// or a flag is not declared, in which case the *SourceError
+// wraps an *ArgsError
+func NewSourceArgsE(args interface{}, opts ...*ArgsOptions) (*SourceArgs, error) {
 	var argsTyped, v = args.([]string)
 	if !v {
-		log.Println("Args param must be in []string type")
-		return nil
+		return nil, newSourceError(SrcNameArgs, ErrInvalidSourceVars, "Args param must be in []string type")
+	}
+	var o *ArgsOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	parser := newArgsParser(o, nil)
+	res := parser.parse(argsTyped)
+	if parser.strict && !parser.errs.empty() {
+		return nil, &SourceError{Source: SrcNameArgs, Reason: ErrSourceInit, Err: parser.errs}
+	}
+	return createSourceArgs(res, nil), nil
+}
+
+func createSourceArgs(res *parsedArgs, parent *SourceArgs) *SourceArgs {
+	s := &SourceArgs{
+		storage:    res.storage,
+		positional: res.positional,
+		command:    res.command,
+		parent:     parent,
 	}
-	return &SourceArgs{
-		storage: createMapFromArgs(argsTyped),
+	if res.sub != nil {
+		s.sub = createSourceArgs(res.sub, s)
 	}
+	return s
 }
 
+// Returns the positional arguments, in their order
+func (s *SourceArgs) Args() []string {
+	return s.positional
+}
 
-// converts arguments in --name=value format to a map of names and values,
-// with names saved without --
-func createMapFromArgs(args []string)  argsParamCollection {
+// Returns the i-th positional argument, or an empty
+// string if there is no such argument
+func (s *SourceArgs) Arg(i int) string {
+	if i < 0 || i >= len(s.positional) {
+		return ""
+	}
+	return s.positional[i]
+}
+
+// Returns the number of positional arguments
+func (s *SourceArgs) NArg() int {
+	return len(s.positional)
+}
+
+// Returns the name of the selected subcommand, or an empty string
+func (s *SourceArgs) CommandName() string {
+	return s.command
+}
+
+// Returns the selected subcommand, or nil if there is none.
+// Its params are the flags given after the subcommand name,
+// and it falls back to the flags of s for other keys
+func (s *SourceArgs) Command() *SourceArgs {
+	return s.sub
+}
+
+// returns the params of s, merged with the params of its
+// parents which are not overridden
+func (s *SourceArgs) params() argsParamCollection {
+	if s.parent == nil {
+		return s.storage
+	}
 	mc := make(argsParamCollection, 0)
-	if len(args) > 0 {
-		rg := regexp.MustCompile(`^\-\-[a-zA-Z\-]+\=.+$`)
-		for _, v := range args {
-			if m := rg.Match([]byte(v)); m == true{
-				spl := strings.SplitN(v, "=", 2)
-				name := strings.Replace(spl[0], "--", "", 1)
-				mc[name] = spl[1]
-			}
-		}
+	for k, v := range s.parent.params() {
+		mc[k] = v
+	}
+	for k, v := range s.storage {
+		mc[k] = v
 	}
 	return mc
 }
@@ -54,6 +146,8 @@ func (s *SourceArgs) Get(name string) interface{} {
 		return ""
 	} else if val, ok := s.storage[name]; ok {
 		return val
+	} else if s.parent != nil {
+		return s.parent.Get(name)
 	}
 	return nil
 }
@@ -65,7 +159,7 @@ func (s *SourceArgs) Scan(regex string) map[string]interface{} {
 		if err != nil {
 			return nil
 		}
-		for k, v := range s.storage {
+		for k, v := range s.params() {
 			if rg.MatchString(k) {
 				mp[k] = v
 			}
@@ -78,7 +172,7 @@ func (s *SourceArgs) Scan(regex string) map[string]interface{} {
 
 func (s *SourceArgs) Iterate(fn func(k string, v interface{})) {
 	if s.Count() > 0 {
-		for k, v := range s.storage {
+		for k, v := range s.params() {
 			fn(k, v)
 		}
 	}
@@ -91,6 +185,8 @@ func (s *SourceArgs) Has(name string) bool {
 		return false
 	} else if _, ok := s.storage[name]; ok {
 		return true
+	} else if s.parent != nil {
+		return s.parent.Has(name)
 	}
 	return false
 }
@@ -100,5 +196,26 @@ func (s *SourceArgs) Count() int64 {
 	if s.storage == nil {
 		return 0
 	}
-	return int64(len(s.storage))
+	return int64(len(s.params()))
+}
+
+// Writes the params as a flat JSON object, with its keys sorted
+func (s *SourceArgs) MarshalJSON() ([]byte, error) {
+	return encodeJSONParams(s.Iterate, JSONOptions{})
+}
+
+// Replaces the params with the ones of a JSON object, see
+// DynamicParams.UnmarshalJSON(). JSON holds the params only, so the
+// positional arguments, the subcommand and the parent are dropped
+func (s *SourceArgs) UnmarshalJSON(data []byte) error {
+	params, err := decodeJSONParams(data)
+	if err != nil {
+		return err
+	}
+	s.storage = argsParamCollection(params)
+	s.positional = nil
+	s.command = ""
+	s.parent = nil
+	s.sub = nil
+	return nil
 }
@@ -0,0 +1,293 @@
+package dyanmic_params
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ArgsOptions declares the flags SourceArgs knows about. Without
+// options only the forms which need no declaration are understood:
+// --key=value, bare --flag and --no-flag, and -abc clusters of
+// boolean short flags
+type ArgsOptions struct {
+	// maps short flags to their long names,
+	// e.g. {"v": "verbose", "p": "port"}
+	Aliases map[string]string
+
+	// long names of the flags which take a value, they read
+	// the value from the next argument when it is not given
+	// with =, as in "--port 8080", "-p 8080" or "-p8080"
+	ValueFlags []string
+
+	// long names of the boolean flags. A bare --name sets a flag
+	// to "true" and --no-name sets it to "false", declaring a flag
+	// is needed only if its own name starts with no-
+	BoolFlags []string
+
+	// long names of the flags which can be given several times,
+	// their values are collected, in order, into a []string:
+	// --header=a --header=b gives []string{"a", "b"}
+	RepeatableFlags []string
+
+	// long names of the flags whose values are key=value pairs,
+	// collected into a map[string]string, so
+	// --label=env=prod --label=team=core gives
+	// map[string]string{"env": "prod", "team": "core"}
+	MapFlags []string
+
+	// subcommands, by their names. When the first positional
+	// argument is the name of a subcommand, the rest of the
+	// arguments are parsed with its options, and the declarations
+	// of the parent (aliases, value and bool flags) are inherited
+	Commands map[string]*ArgsOptions
+
+	// the first argument is the name of the program, as in
+	// os.Args, and is not a positional argument
+	SkipProgramName bool
+
+	// makes NewSourceArgsE() fail with an *ArgsError when an
+	// argument is malformed or a flag is not declared. The declared
+	// flags are the ones listed in the options above (including the
+	// targets of Aliases) and in KnownFlags. Subcommands inherit it
+	Strict bool
+
+	// long names of the flags which are neither value, bool,
+	// repeatable nor map flags, but are still allowed in Strict mode
+	KnownFlags []string
+}
+
+var argsKeyRg = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.\-]*$`)
+
+type argsParser struct {
+	aliases    map[string]string
+	valueFlags map[string]bool
+	boolFlags  map[string]bool
+	repeatable map[string]bool
+	mapFlags   map[string]bool
+	known      map[string]bool
+	commands   map[string]*ArgsOptions
+	skipFirst  bool
+	strict     bool
+	errs       *ArgsError
+}
+
+// the result of parsing the arguments of one command
+type parsedArgs struct {
+	storage    argsParamCollection
+	positional []string
+	command    string
+	sub        *parsedArgs
+}
+
+// creates a parser for opts, the declarations of parent
+// (which can be nil) are inherited
+func newArgsParser(opts *ArgsOptions, parent *argsParser) *argsParser {
+	p := &argsParser{
+		aliases:    make(map[string]string, 0),
+		valueFlags: make(map[string]bool, 0),
+		boolFlags:  make(map[string]bool, 0),
+		repeatable: make(map[string]bool, 0),
+		mapFlags:   make(map[string]bool, 0),
+		known:      make(map[string]bool, 0),
+		errs:       &ArgsError{},
+	}
+	if parent != nil {
+		p.strict = parent.strict
+		p.errs = parent.errs
+		for name := range parent.known {
+			p.known[name] = true
+		}
+		for short, long := range parent.aliases {
+			p.aliases[short] = long
+		}
+		for name := range parent.valueFlags {
+			p.valueFlags[name] = true
+		}
+		for name := range parent.boolFlags {
+			p.boolFlags[name] = true
+		}
+		for name := range parent.repeatable {
+			p.repeatable[name] = true
+		}
+		for name := range parent.mapFlags {
+			p.mapFlags[name] = true
+		}
+	}
+	if opts == nil {
+		return p
+	}
+	p.commands = opts.Commands
+	p.skipFirst = opts.SkipProgramName && parent == nil
+	p.strict = p.strict || opts.Strict
+	for short, long := range opts.Aliases {
+		p.aliases[short] = long
+		p.known[long] = true
+	}
+	for _, name := range opts.ValueFlags {
+		p.valueFlags[name] = true
+		p.known[name] = true
+	}
+	for _, name := range opts.BoolFlags {
+		p.boolFlags[name] = true
+		p.known[name] = true
+	}
+	for _, name := range opts.RepeatableFlags {
+		p.repeatable[name] = true
+		p.valueFlags[name] = true
+		p.known[name] = true
+	}
+	for _, name := range opts.MapFlags {
+		p.mapFlags[name] = true
+		p.valueFlags[name] = true
+		p.known[name] = true
+	}
+	for _, name := range opts.KnownFlags {
+		p.known[name] = true
+	}
+	return p
+}
+
+// parses the arguments in GNU style. Values are stored as strings,
+// and boolean flags as "true" or "false". Arguments which are not
+// flags, and everything after the -- terminator, are positional
+func (p *argsParser) parse(args []string) *parsedArgs {
+	res := &parsedArgs{
+		storage:    make(argsParamCollection, 0),
+		positional: make([]string, 0),
+	}
+	if p.skipFirst && len(args) > 0 {
+		args = args[1:]
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			res.positional = append(res.positional, args[i+1:]...)
+			break
+		} else if strings.HasPrefix(arg, "--") {
+			i += p.parseLong(arg, args[i+1:], res.storage)
+		} else if len(arg) > 1 && arg[0] == '-' {
+			i += p.parseShort(arg, args[i+1:], res.storage)
+		} else if cmd, ok := p.commands[arg]; ok && len(res.positional) == 0 {
+			res.command = arg
+			res.sub = newArgsParser(cmd, p).parse(args[i+1:])
+			break
+		} else {
+			res.positional = append(res.positional, arg)
+		}
+	}
+	return res
+}
+
+// parses "--name=value", "--name" or "--no-name" and returns
+// the number of following arguments which were consumed
+func (p *argsParser) parseLong(arg string, rest []string, mc argsParamCollection) int {
+	spl := strings.SplitN(arg[2:], "=", 2)
+	name := spl[0]
+	if !argsKeyRg.MatchString(name) {
+		p.malformed(arg, "")
+		return 0
+	}
+	if len(spl) == 2 {
+		p.set(mc, arg, name, spl[1])
+		return 0
+	}
+	if p.valueFlags[name] {
+		if len(rest) == 0 {
+			p.malformed(arg, name)
+			return 0
+		}
+		p.set(mc, arg, name, rest[0])
+		return 1
+	}
+	if strings.HasPrefix(name, "no-") && !p.boolFlags[name] && len(name) > 3 {
+		p.set(mc, arg, name[3:], "false")
+		return 0
+	}
+	p.set(mc, arg, name, "true")
+	return 0
+}
+
+// parses a cluster of short flags such as "-v", "-abc", "-p8080"
+// or "-p=8080" and returns the number of following arguments which
+// were consumed. A malformed cluster is dropped as a whole
+func (p *argsParser) parseShort(arg string, rest []string, mc argsParamCollection) int {
+	body := arg[1:]
+	found := make(map[string]string, 0)
+	consumed := 0
+	for j := 0; j < len(body); j++ {
+		short := body[j : j+1]
+		if !argsKeyRg.MatchString(short) {
+			// most likely a long flag with a single dash, e.g. -port=80
+			p.malformed(arg, strings.SplitN(body, "=", 2)[0])
+			return 0
+		}
+		name := p.name(short)
+		if !p.valueFlags[name] {
+			found[name] = "true"
+			continue
+		}
+		if value := body[j+1:]; value != "" {
+			found[name] = strings.TrimPrefix(value, "=")
+		} else if len(rest) > 0 {
+			found[name] = rest[0]
+			consumed = 1
+		} else {
+			p.malformed(arg, name)
+			return 0
+		}
+		break
+	}
+	for k, v := range found {
+		p.set(mc, arg, k, v)
+	}
+	return consumed
+}
+
+// stores the value of a flag, repeatable flags are appended
+// to their list, and map flags are split into key and value
+func (p *argsParser) set(mc argsParamCollection, arg, name, value string) {
+	if p.strict && !p.known[name] {
+		p.errs.Unknown = append(p.errs.Unknown, ArgsIssue{
+			Arg:        arg,
+			Name:       name,
+			Suggestion: suggestFlag(name, p.known),
+		})
+	}
+	if p.repeatable[name] {
+		list, _ := mc[name].([]string)
+		mc[name] = append(list, value)
+	} else if p.mapFlags[name] {
+		mp, ok := mc[name].(map[string]string)
+		if !ok {
+			mp = make(map[string]string, 0)
+			mc[name] = mp
+		}
+		spl := strings.SplitN(value, "=", 2)
+		if len(spl) == 2 {
+			mp[spl[0]] = spl[1]
+		} else {
+			mp[spl[0]] = ""
+			p.errs.Malformed = append(p.errs.Malformed, ArgsIssue{Arg: arg, Name: name})
+		}
+	} else {
+		mc[name] = value
+	}
+}
+
+// records an argument which cannot be parsed, name is the
+// flag it most likely refers to, if it could be read
+func (p *argsParser) malformed(arg, name string) {
+	issue := ArgsIssue{Arg: arg, Name: name}
+	if name != "" {
+		issue.Suggestion = suggestFlag(name, p.known)
+	}
+	p.errs.Malformed = append(p.errs.Malformed, issue)
+}
+
+// returns the long name of a short flag
+func (p *argsParser) name(short string) string {
+	if long, ok := p.aliases[short]; ok {
+		return long
+	}
+	return short
+}
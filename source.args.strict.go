@@ -0,0 +1,109 @@
+package dyanmic_params
+
+import (
+	"sort"
+	"strings"
+)
+
+// ArgsIssue describes one problem found in the arguments
+type ArgsIssue struct {
+	// the argument as it was given
+	Arg string
+
+	// the name of the flag, if it could be read
+	Name string
+
+	// the closest declared flag, if there is one
+	// close enough to be a typo of Name
+	Suggestion string
+}
+
+func (i ArgsIssue) String() string {
+	msg := i.Arg
+	if i.Suggestion != "" {
+		msg += " (did you mean --" + i.Suggestion + "?)"
+	}
+	return msg
+}
+
+// ArgsError is returned by NewSourceArgsE() in strict mode
+// (ArgsOptions.Strict) and lists all malformed arguments and
+// undeclared flags at once
+type ArgsError struct {
+	Malformed []ArgsIssue
+	Unknown   []ArgsIssue
+}
+
+func (e *ArgsError) Error() string {
+	var parts []string
+	if len(e.Malformed) > 0 {
+		parts = append(parts, "malformed arguments: "+joinArgsIssues(e.Malformed))
+	}
+	if len(e.Unknown) > 0 {
+		parts = append(parts, "unknown flags: "+joinArgsIssues(e.Unknown))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (e *ArgsError) empty() bool {
+	return len(e.Malformed) == 0 && len(e.Unknown) == 0
+}
+
+func joinArgsIssues(issues []ArgsIssue) string {
+	list := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		list = append(list, issue.String())
+	}
+	return strings.Join(list, ", ")
+}
+
+// returns the known flag with the smallest edit distance to
+// name, if that distance is small enough to be a typo
+func suggestFlag(name string, known map[string]bool) string {
+	names := make([]string, 0, len(known))
+	for k := range known {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	best, bestDistance := "", -1
+	for _, k := range names {
+		d := editDistance(name, k)
+		if bestDistance == -1 || d < bestDistance {
+			best, bestDistance = k, d
+		}
+	}
+	if bestDistance == -1 || bestDistance > 2 || bestDistance >= len(name) {
+		return ""
+	}
+	return best
+}
+
+// the Levenshtein distance of a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, minInt(cur[j-1]+1, prev[j-1]+cost))
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
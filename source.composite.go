@@ -0,0 +1,152 @@
+package dyanmic_params
+
+const SrcNameComposite = "source.composite"
+
+// SourceComposite stacks several sources on top of each other.
+// Sources are ordered by priority, the first one has the highest
+// priority, so a key found in it hides the same key in the others.
+//
+// A typical stack is args > env > file > defaults:
+//  NewSourceComposite(NewSourceArgs(os.Args), NewSourceEnv(nil),
+//      NewSourceJSON("config.json"), defaults)
+type SourceComposite struct {
+	sources []ParamsSource
+}
+
+func init() {
+	RegisterSource(SrcNameComposite, func(vars ...interface{}) (ParamsSource, error) {
+		src, err := createCompositeFromVars(vars)
+		if err != nil {
+			return nil, err
+		}
+		return src, nil
+	})
+}
+
+// nil sources, including typed nil ones such as a *SourceJSON which
+// failed to load, are skipped. NewSourceE(SrcNameComposite, ...)
+// reports them as ErrInvalidSourceVars instead
+func NewSourceComposite(sources ...ParamsSource) *SourceComposite {
+	var list []ParamsSource
+	for _, src := range sources {
+		if !isNilSource(src) {
+			list = append(list, src)
+		}
+	}
+	return &SourceComposite{
+		sources: list,
+	}
+}
+
+// converts the vars passed to NewSource() into the list of sources
+func createCompositeFromVars(vars []interface{}) (*SourceComposite, error) {
+	sources := make([]ParamsSource, 0, len(vars))
+	for _, v := range vars {
+		src, ok := v.(ParamsSource)
+		if !ok {
+			return nil, newSourceError(SrcNameComposite, ErrInvalidSourceVars, "SourceComposite accepts only ParamsSource values")
+		}
+		if isNilSource(src) {
+			// e.g. a *SourceJSON which failed to load, NewSourceE()
+			// must report it rather than stack the other sources
+			return nil, newSourceError(SrcNameComposite, ErrInvalidSourceVars, "SourceComposite does not accept nil sources")
+		}
+		sources = append(sources, src)
+	}
+	return NewSourceComposite(sources...), nil
+}
+
+// Returns the stacked sources, in their priority order
+func (s *SourceComposite) Sources() []ParamsSource {
+	return s.sources
+}
+
+// adds the param to the source with the highest priority, a
+// composite without sources gets a SrcNameInternal one to hold it
+func (s *SourceComposite) Add(name string, value interface{}) ParamsSource {
+	if len(s.sources) == 0 {
+		s.sources = []ParamsSource{NewSourceInternal()}
+	}
+	s.sources[0].Add(name, value)
+	return s
+}
+
+// returns the value of the first source which has the key
+func (s *SourceComposite) Get(name string) interface{} {
+	for _, src := range s.sources {
+		if src.Has(name) {
+			return src.Get(name)
+		}
+	}
+	return nil
+}
+
+func (s *SourceComposite) Has(name string) bool {
+	for _, src := range s.sources {
+		if src.Has(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// merges the scan result of all sources, for a key found in
+// several sources, the value of the highest priority one is kept
+func (s *SourceComposite) Scan(regex string) map[string]interface{} {
+	var mp map[string]interface{}
+	for _, src := range s.sources {
+		for k, v := range src.Scan(regex) {
+			if mp == nil {
+				mp = make(map[string]interface{}, 0)
+			}
+			if _, ok := mp[k]; !ok {
+				mp[k] = v
+			}
+		}
+	}
+	return mp
+}
+
+// iterates over the unique keys of all sources, with
+// the value of the highest priority source
+func (s *SourceComposite) Iterate(fn func(k string, v interface{})) {
+	for k, v := range s.merge() {
+		fn(k, v)
+	}
+	return
+}
+
+// returns the number of unique keys
+func (s *SourceComposite) Count() int64 {
+	return int64(len(s.merge()))
+}
+
+func (s *SourceComposite) merge() map[string]interface{} {
+	mp := make(map[string]interface{}, 0)
+	for _, src := range s.sources {
+		src.Iterate(func(k string, v interface{}) {
+			if _, ok := mp[k]; !ok {
+				mp[k] = v
+			}
+		})
+	}
+	return mp
+}
+
+// Writes the merged params of the sources as a flat JSON object,
+// with its keys sorted
+func (s *SourceComposite) MarshalJSON() ([]byte, error) {
+	return encodeJSONParams(s.Iterate, JSONOptions{})
+}
+
+// Replaces the params with the ones of a JSON object, see
+// DynamicParams.UnmarshalJSON(). The stacked sources are replaced
+// by a single SrcNameInternal source holding the params
+func (s *SourceComposite) UnmarshalJSON(data []byte) error {
+	src := NewSourceInternal()
+	if err := src.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	s.sources = []ParamsSource{src}
+	return nil
+}
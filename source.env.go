@@ -0,0 +1,185 @@
+package dyanmic_params
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+const SrcNameEnv = "source.env"
+
+type envParamCollection map[string]interface{}
+
+// EnvOptions controls which environment variables SourceEnv
+// picks up and how their names are turned into param keys.
+//
+// With the default options APP_DB__HOST_NAME (and Prefix "APP_")
+// becomes db.host-name: the prefix is removed, the name is lower
+// cased, NestedSeparator is replaced with KeyDelimiter and
+// WordSeparator with "-"
+type EnvOptions struct {
+	// only variables starting with Prefix are loaded,
+	// empty means all of them
+	Prefix string
+
+	// the list of variables in KEY=value form, if nil,
+	// os.Environ() is used
+	Environ []string
+
+	// separates the levels of a nested key, default is "__"
+	NestedSeparator string
+
+	// replaces NestedSeparator in keys, default is KeyDelimiter,
+	// set it to "-" to get db-host instead of db.host
+	KeyDelimiter string
+
+	// separates the words of a key, default is "_"
+	WordSeparator string
+
+	// keeps the case of the names as they are
+	KeepCase bool
+}
+
+type SourceEnv struct {
+	storage envParamCollection
+}
+
+func init() {
+	RegisterSource(SrcNameEnv, func(vars ...interface{}) (ParamsSource, error) {
+		if len(vars) == 0 {
+			return NewSourceEnv(nil), nil
+		} else if opts, ok := vars[0].(*EnvOptions); ok {
+			return NewSourceEnv(opts), nil
+		} else if opts, ok := vars[0].(EnvOptions); ok {
+			return NewSourceEnv(&opts), nil
+		}
+		return nil, newSourceError("", ErrInvalidSourceVars, "SourceEnv accepts only an EnvOptions passed to NewSource()")
+	})
+}
+
+// opts can be nil to load every environment variable of
+// the process with the default mapping
+func NewSourceEnv(opts *EnvOptions) *SourceEnv {
+	if opts == nil {
+		opts = &EnvOptions{}
+	}
+	return &SourceEnv{
+		storage: createMapFromEnv(opts),
+	}
+}
+
+func createMapFromEnv(opts *EnvOptions) envParamCollection {
+	environ := opts.Environ
+	if environ == nil {
+		environ = os.Environ()
+	}
+	mc := make(envParamCollection, 0)
+	for _, v := range environ {
+		spl := strings.SplitN(v, "=", 2)
+		if len(spl) != 2 || !strings.HasPrefix(spl[0], opts.Prefix) {
+			continue
+		}
+		name := envNameToKey(strings.TrimPrefix(spl[0], opts.Prefix), opts)
+		if name == "" {
+			continue
+		}
+		mc[name] = spl[1]
+	}
+	return mc
+}
+
+// converts the name of a variable, without its prefix,
+// to a key, e.g. DB__HOST_NAME -> db.host-name
+func envNameToKey(name string, opts *EnvOptions) string {
+	nested, delimiter, word := opts.NestedSeparator, opts.KeyDelimiter, opts.WordSeparator
+	if nested == "" {
+		nested = "__"
+	}
+	if delimiter == "" {
+		delimiter = KeyDelimiter
+	}
+	if word == "" {
+		word = "_"
+	}
+	if !opts.KeepCase {
+		name = strings.ToLower(name)
+	}
+	parts := strings.Split(name, nested)
+	for i, part := range parts {
+		parts[i] = strings.Replace(part, word, "-", -1)
+	}
+	return strings.Join(parts, delimiter)
+}
+
+func (s *SourceEnv) Add(name string, value interface{}) ParamsSource {
+	s.storage[name] = value
+	return s
+}
+
+func (s *SourceEnv) Get(name string) interface{} {
+	if s.storage == nil {
+		return ""
+	} else if val, ok := s.storage[name]; ok {
+		return val
+	}
+	return nil
+}
+
+func (s *SourceEnv) Scan(regex string) map[string]interface{} {
+	if s.Count() > 0 {
+		mp := make(map[string]interface{}, 0)
+		rg, err := regexp.Compile(regex)
+		if err != nil {
+			return nil
+		}
+		for k, v := range s.storage {
+			if rg.MatchString(k) {
+				mp[k] = v
+			}
+		}
+
+		return mp
+	}
+	return nil
+}
+
+func (s *SourceEnv) Iterate(fn func(k string, v interface{})) {
+	if s.Count() > 0 {
+		for k, v := range s.storage {
+			fn(k, v)
+		}
+	}
+	return
+}
+
+func (s *SourceEnv) Has(name string) bool {
+	if s.storage == nil {
+		return false
+	} else if _, ok := s.storage[name]; ok {
+		return true
+	}
+	return false
+}
+
+func (s *SourceEnv) Count() int64 {
+	if s.storage == nil {
+		return 0
+	}
+	return int64(len(s.storage))
+}
+
+// Writes the params as a flat JSON object, with its keys sorted
+func (s *SourceEnv) MarshalJSON() ([]byte, error) {
+	return encodeJSONParams(s.Iterate, JSONOptions{})
+}
+
+// Replaces the params with the ones of a JSON object,
+// see DynamicParams.UnmarshalJSON()
+func (s *SourceEnv) UnmarshalJSON(data []byte) error {
+	params, err := decodeJSONParams(data)
+	if err != nil {
+		return err
+	}
+	s.storage = envParamCollection(params)
+	return nil
+}
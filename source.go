@@ -1,6 +1,19 @@
 package dyanmic_params
 
-import "log"
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// KeyDelimiter joins the names of nested values when a source
+// flattens a compound document (JSON, YAML ...) into params,
+// so {"db": {"host": "x"}} becomes the key "db.host"
+const KeyDelimiter = "."
 
 type ParamsIteratorFn = func(key string, value interface{})
 
@@ -25,14 +38,142 @@ type ParamsSource interface {
 	Iterate(fn ParamsIteratorFn)
 }
 
+const (
+	ErrUnknownSource     = "unknown source"
+	ErrMissingSourceVars = "missing source vars"
+	ErrInvalidSourceVars = "invalid source vars"
+	ErrSourceInit        = "source initialization failed"
+)
+
+// SourceError is returned when a source cannot be created.
+// Reason is one of ErrUnknownSource, ErrMissingSourceVars,
+// ErrInvalidSourceVars or ErrSourceInit, and Err (if any)
+// is the underlying error
+type SourceError struct {
+	Source string
+	Reason string
+	Err    error
+}
+
+func (e *SourceError) Error() string {
+	msg := e.Reason
+	if e.Source != "" {
+		msg = e.Source + ": " + msg
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+func newSourceError(source, reason, details string) *SourceError {
+	e := &SourceError{Source: source, Reason: reason}
+	if details != "" {
+		e.Err = errors.New(details)
+	}
+	return e
+}
+
+// Creates a registered source by its name, vars are passed
+// to the factory of the source. It logs the error and returns nil
+// if the source cannot be created, use NewSourceE() to handle it
 func NewSource(name string, vars ...interface{}) ParamsSource {
-	if name == SrcNameInternal {
-		return NewSourceInternal()
-	} else if name == SrcNameArgs {
-		if len(vars) == 0 {
-			log.Fatal("SourceArgs must have a args collection passed to NewSource()")
+	src, err := NewSourceE(name, vars...)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	return src
+}
+
+// Like NewSource(), but returns a *SourceError if the source is not
+// registered, its vars are missing or mistyped, or it fails to load
+func NewSourceE(name string, vars ...interface{}) (ParamsSource, error) {
+	factory := lookupSource(name)
+	if factory == nil {
+		return nil, newSourceError(name, ErrUnknownSource, "")
+	}
+	src, err := factory(vars...)
+	if err != nil {
+		var se *SourceError
+		if errors.As(err, &se) {
+			if se.Source == "" {
+				se.Source = name
+			}
+			return nil, se
 		}
-		return NewSourceArgs(vars[0])
+		return nil, &SourceError{Source: name, Reason: ErrSourceInit, Err: err}
 	}
-	return nil
-}
\ No newline at end of file
+	if isNilSource(src) {
+		return nil, newSourceError(name, ErrSourceInit, "factory returned no source")
+	}
+	return src, nil
+}
+
+// reports whether src is nil, or an interface holding a nil pointer,
+// map etc., as the NewSource*() constructors return when they fail
+func isNilSource(src ParamsSource) bool {
+	if src == nil {
+		return true
+	}
+	v := reflect.ValueOf(src)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// reads the content of a document based source. input can be
+// a []byte, an io.Reader, or a string; a multi-line string, or one
+// which isInline() accepts, is the document itself, and any other
+// string is a file path, whose os.Stat() error is returned as is
+func readSourceInput(input interface{}, isInline func(string) bool) ([]byte, error) {
+	switch v := input.(type) {
+	case []byte:
+		return v, nil
+	case io.Reader:
+		return ioutil.ReadAll(v)
+	case string:
+		if strings.ContainsAny(v, "\r\n") || isInline(strings.TrimSpace(v)) {
+			return []byte(v), nil
+		}
+		st, err := os.Stat(v)
+		if err != nil {
+			return nil, err
+		}
+		if !st.Mode().IsRegular() {
+			return nil, errors.New(v + ": not a regular file")
+		}
+		return ioutil.ReadFile(v)
+	}
+	return nil, errors.New("source input must be a file path, an io.Reader, a string or []byte")
+}
+
+// walks a decoded document and stores every leaf value in out,
+// nested objects are joined using KeyDelimiter. Lists are kept as
+// they are, and an empty object is kept so that Has() finds it
+func flattenParams(prefix string, value interface{}, out map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+		}
+		for k, item := range v {
+			flattenParams(joinKey(prefix, k), item, out)
+		}
+	default:
+		out[prefix] = v
+	}
+}
+
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + KeyDelimiter + name
+}
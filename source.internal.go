@@ -11,6 +11,12 @@ type SourceInternal struct {
 	storage internalParamCollection
 }
 
+func init() {
+	RegisterSource(SrcNameInternal, func(vars ...interface{}) (ParamsSource, error) {
+		return NewSourceInternal(), nil
+	})
+}
+
 func NewSourceInternal() *SourceInternal {
 	return &SourceInternal{
 		storage: make(internalParamCollection, 0),
@@ -73,3 +79,19 @@ func (s *SourceInternal) Count() int64 {
 	}
 	return int64(len(s.storage))
 }
+
+// Writes the params as a flat JSON object, with its keys sorted
+func (s *SourceInternal) MarshalJSON() ([]byte, error) {
+	return encodeJSONParams(s.Iterate, JSONOptions{})
+}
+
+// Replaces the params with the ones of a JSON object,
+// see DynamicParams.UnmarshalJSON()
+func (s *SourceInternal) UnmarshalJSON(data []byte) error {
+	params, err := decodeJSONParams(data)
+	if err != nil {
+		return err
+	}
+	s.storage = internalParamCollection(params)
+	return nil
+}
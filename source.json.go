@@ -0,0 +1,169 @@
+package dyanmic_params
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"regexp"
+	"strings"
+)
+
+const SrcNameJSON = "source.json"
+
+type jsonParamCollection map[string]interface{}
+
+// SourceJSON loads its params from a JSON object. Nested objects
+// are flattened, so {"db": {"host": "x"}} is stored as "db.host",
+// and numbers are kept as json.Number so they can be read with
+// GetAsInt(), GetAsInt64() etc. without losing precision
+type SourceJSON struct {
+	storage jsonParamCollection
+}
+
+func init() {
+	RegisterSource(SrcNameJSON, func(vars ...interface{}) (ParamsSource, error) {
+		if len(vars) == 0 {
+			return nil, newSourceError("", ErrMissingSourceVars, "SourceJSON must have a file path, reader or JSON string passed to NewSource()")
+		}
+		src, err := NewSourceJSONE(vars[0])
+		if err != nil {
+			return nil, err
+		}
+		return src, nil
+	})
+}
+
+// input can be a path to a JSON file, an io.Reader,
+// a JSON string or a []byte
+func NewSourceJSON(input interface{}) *SourceJSON {
+	src, err := NewSourceJSONE(input)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	return src
+}
+
+// Like NewSourceJSON(), but returns a *SourceError if input
+// cannot be read or is not a valid JSON object
+func NewSourceJSONE(input interface{}) (*SourceJSON, error) {
+	storage, err := createMapFromJSON(input)
+	if err != nil {
+		return nil, &SourceError{Source: SrcNameJSON, Reason: ErrSourceInit, Err: err}
+	}
+	return &SourceJSON{
+		storage: storage,
+	}, nil
+}
+
+func createMapFromJSON(input interface{}) (jsonParamCollection, error) {
+	data, err := readSourceInput(input, isInlineJSON)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if err := expectJSONEnd(dec); err != nil {
+		return nil, err
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("JSON document must be an object")
+	}
+	mc := make(jsonParamCollection, 0)
+	flattenParams("", root, mc)
+	return mc, nil
+}
+
+// a single line string is a JSON document only if it is an object
+// or an array, anything else is a file path
+func isInlineJSON(s string) bool {
+	return strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[")
+}
+
+// fails if anything but whitespace follows the decoded value
+func expectJSONEnd(dec *json.Decoder) error {
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("unexpected data after the JSON document")
+	}
+	return nil
+}
+
+func (s *SourceJSON) Add(name string, value interface{}) ParamsSource {
+	s.storage[name] = value
+	return s
+}
+
+func (s *SourceJSON) Get(name string) interface{} {
+	if s.storage == nil {
+		return ""
+	} else if val, ok := s.storage[name]; ok {
+		return val
+	}
+	return nil
+}
+
+func (s *SourceJSON) Scan(regex string) map[string]interface{} {
+	if s.Count() > 0 {
+		mp := make(map[string]interface{}, 0)
+		rg, err := regexp.Compile(regex)
+		if err != nil {
+			return nil
+		}
+		for k, v := range s.storage {
+			if rg.MatchString(k) {
+				mp[k] = v
+			}
+		}
+
+		return mp
+	}
+	return nil
+}
+
+func (s *SourceJSON) Iterate(fn func(k string, v interface{})) {
+	if s.Count() > 0 {
+		for k, v := range s.storage {
+			fn(k, v)
+		}
+	}
+	return
+}
+
+func (s *SourceJSON) Has(name string) bool {
+	if s.storage == nil {
+		return false
+	} else if _, ok := s.storage[name]; ok {
+		return true
+	}
+	return false
+}
+
+func (s *SourceJSON) Count() int64 {
+	if s.storage == nil {
+		return 0
+	}
+	return int64(len(s.storage))
+}
+
+// Writes the params as a flat JSON object, with its keys sorted
+func (s *SourceJSON) MarshalJSON() ([]byte, error) {
+	return encodeJSONParams(s.Iterate, JSONOptions{})
+}
+
+// Replaces the params with the ones of a JSON object,
+// see DynamicParams.UnmarshalJSON()
+func (s *SourceJSON) UnmarshalJSON(data []byte) error {
+	params, err := decodeJSONParams(data)
+	if err != nil {
+		return err
+	}
+	s.storage = jsonParamCollection(params)
+	return nil
+}
@@ -0,0 +1,64 @@
+package dyanmic_params
+
+import (
+	"sort"
+	"sync"
+)
+
+// SourceFactory creates a source from the vars passed
+// to NewSource() or NewDynamicParams(). The returned error is
+// reported by NewSourceE() as a *SourceError, a factory can return
+// a *SourceError itself to choose its Reason
+type SourceFactory func(vars ...interface{}) (ParamsSource, error)
+
+var (
+	registryMx = &sync.RWMutex{}
+	registry   = make(map[string]SourceFactory)
+)
+
+// Makes a source available by name to NewSource() and NewDynamicParams().
+// It is meant to be called from the init() function of the package which
+// provides the source, the same way database/sql drivers register themselves.
+//
+// It panics if name is empty, factory is nil, or a source with
+// the same name is already registered
+func RegisterSource(name string, factory SourceFactory) {
+	registryMx.Lock()
+	defer registryMx.Unlock()
+	if name == "" {
+		panic("dynamic-params: RegisterSource name is empty")
+	}
+	if factory == nil {
+		panic("dynamic-params: RegisterSource factory is nil for " + name)
+	}
+	if _, ok := registry[name]; ok {
+		panic("dynamic-params: RegisterSource called twice for " + name)
+	}
+	registry[name] = factory
+}
+
+// Returns a sorted list of the names of the registered sources
+func RegisteredSources() []string {
+	registryMx.RLock()
+	defer registryMx.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Checks to see if a source with the given name is registered
+func IsSourceRegistered(name string) bool {
+	registryMx.RLock()
+	defer registryMx.RUnlock()
+	_, ok := registry[name]
+	return ok
+}
+
+func lookupSource(name string) SourceFactory {
+	registryMx.RLock()
+	defer registryMx.RUnlock()
+	return registry[name]
+}
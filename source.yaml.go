@@ -0,0 +1,211 @@
+package dyanmic_params
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const SrcNameYAML = "source.yaml"
+
+type yamlParamCollection map[string]interface{}
+
+// SourceYAML loads its params from a YAML document. Nested mappings
+// are flattened the same way SourceJSON does it, anchors and aliases
+// are resolved, and ints and floats are kept as json.Number so
+// GetAsInt(), GetAsInt64() etc. work on them.
+//
+// If the input holds several documents (separated by ---), they are
+// merged in order, so a key in a later document overrides the
+// same key in the earlier ones
+type SourceYAML struct {
+	storage yamlParamCollection
+}
+
+func init() {
+	RegisterSource(SrcNameYAML, func(vars ...interface{}) (ParamsSource, error) {
+		if len(vars) == 0 {
+			return nil, newSourceError("", ErrMissingSourceVars, "SourceYAML must have a file path, reader or YAML string passed to NewSource()")
+		}
+		src, err := NewSourceYAMLE(vars[0])
+		if err != nil {
+			return nil, err
+		}
+		return src, nil
+	})
+}
+
+// input can be a path to a YAML file, an io.Reader,
+// a YAML string or a []byte
+func NewSourceYAML(input interface{}) *SourceYAML {
+	src, err := NewSourceYAMLE(input)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	return src
+}
+
+// Like NewSourceYAML(), but returns a *SourceError if input
+// cannot be read or is not a valid YAML mapping
+func NewSourceYAMLE(input interface{}) (*SourceYAML, error) {
+	storage, err := createMapFromYAML(input)
+	if err != nil {
+		return nil, &SourceError{Source: SrcNameYAML, Reason: ErrSourceInit, Err: err}
+	}
+	return &SourceYAML{
+		storage: storage,
+	}, nil
+}
+
+func createMapFromYAML(input interface{}) (yamlParamCollection, error) {
+	data, err := readSourceInput(input, isInlineYAML)
+	if err != nil {
+		return nil, err
+	}
+	mc := make(yamlParamCollection, 0)
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc interface{}
+		if err := dec.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if doc == nil {
+			continue
+		}
+		root, ok := normalizeYAMLValue(doc).(map[string]interface{})
+		if !ok {
+			return nil, errors.New("YAML document must be a mapping")
+		}
+		flattenParams("", root, mc)
+	}
+	return mc, nil
+}
+
+// converts the values produced by yaml.v3 to the same shapes the
+// JSON source produces: mappings with string keys and numbers
+// as json.Number
+func normalizeYAMLValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = normalizeYAMLValue(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		mp := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			mp[fmt.Sprint(k)] = normalizeYAMLValue(item)
+		}
+		return mp
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeYAMLValue(item)
+		}
+		return v
+	case int:
+		return json.Number(strconv.Itoa(v))
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(v, 10))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return v
+		}
+		return json.Number(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return val
+}
+
+// a single line string is a YAML document if it is a flow
+// collection, a document marker or a "key: value" pair, anything
+// else is a file path
+func isInlineYAML(s string) bool {
+	return strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") ||
+		strings.HasPrefix(s, "---") || strings.Contains(s, ": ") || strings.HasSuffix(s, ":")
+}
+
+func (s *SourceYAML) Add(name string, value interface{}) ParamsSource {
+	s.storage[name] = value
+	return s
+}
+
+func (s *SourceYAML) Get(name string) interface{} {
+	if s.storage == nil {
+		return ""
+	} else if val, ok := s.storage[name]; ok {
+		return val
+	}
+	return nil
+}
+
+func (s *SourceYAML) Scan(regex string) map[string]interface{} {
+	if s.Count() > 0 {
+		mp := make(map[string]interface{}, 0)
+		rg, err := regexp.Compile(regex)
+		if err != nil {
+			return nil
+		}
+		for k, v := range s.storage {
+			if rg.MatchString(k) {
+				mp[k] = v
+			}
+		}
+
+		return mp
+	}
+	return nil
+}
+
+func (s *SourceYAML) Iterate(fn func(k string, v interface{})) {
+	if s.Count() > 0 {
+		for k, v := range s.storage {
+			fn(k, v)
+		}
+	}
+	return
+}
+
+func (s *SourceYAML) Has(name string) bool {
+	if s.storage == nil {
+		return false
+	} else if _, ok := s.storage[name]; ok {
+		return true
+	}
+	return false
+}
+
+func (s *SourceYAML) Count() int64 {
+	if s.storage == nil {
+		return 0
+	}
+	return int64(len(s.storage))
+}
+
+// Writes the params as a flat JSON object, with its keys sorted
+func (s *SourceYAML) MarshalJSON() ([]byte, error) {
+	return encodeJSONParams(s.Iterate, JSONOptions{})
+}
+
+// Replaces the params with the ones of a JSON object,
+// see DynamicParams.UnmarshalJSON()
+func (s *SourceYAML) UnmarshalJSON(data []byte) error {
+	params, err := decodeJSONParams(data)
+	if err != nil {
+		return err
+	}
+	s.storage = yamlParamCollection(params)
+	return nil
+}
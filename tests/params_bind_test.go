@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+type bindDBConfig struct {
+	Host    string        `param:"host" default:"localhost"`
+	Port    int           `param:"port" default:"5432"`
+	Timeout time.Duration `param:"timeout" required:"true"`
+}
+
+type bindConfig struct {
+	Name      string `param:"name" required:"true"`
+	MaxConns  uint16
+	Debug     bool
+	Ratio     *float64
+	Origins   []string      `param:"origins"`
+	Ports     []int         `param:"ports"`
+	DB        bindDBConfig  `param:"db"`
+	Cache     *bindDBConfig `param:"cache"`
+	Ignored   string        `param:"-"`
+	unexposed string
+}
+
+func TestDynamicParams_BindFromArgs(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--name=billing", "--max-conns=10", "--debug",
+		"--ratio=0.25", "--origins=a.com, b.com", "--ports=80,443", "--db.timeout=5s", "--cache.host=redis",
+		"--cache.timeout=1m", "--ignored=x"})
+
+	var cfg bindConfig
+	assert.NoError(t, p.Bind(&cfg))
+	assert.Equal(t, "billing", cfg.Name)
+	assert.Equal(t, uint16(10), cfg.MaxConns)
+	assert.True(t, cfg.Debug)
+	if assert.NotNil(t, cfg.Ratio) {
+		assert.Equal(t, 0.25, *cfg.Ratio)
+	}
+	assert.Equal(t, []string{"a.com", "b.com"}, cfg.Origins)
+	assert.Equal(t, []int{80, 443}, cfg.Ports)
+	assert.Equal(t, bindDBConfig{Host: "localhost", Port: 5432, Timeout: 5 * time.Second}, cfg.DB)
+	if assert.NotNil(t, cfg.Cache) {
+		assert.Equal(t, "redis", cfg.Cache.Host)
+		assert.Equal(t, time.Minute, cfg.Cache.Timeout)
+	}
+	assert.Equal(t, "", cfg.Ignored)
+}
+
+func TestDynamicParams_BindFromJSON(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"name": "billing", "max-conns": 20, "ports": [80, 443],
+		"db": {"port": 6432, "timeout": "2s"}, "cache": {"timeout": "1s"}}`)
+	var cfg bindConfig
+	assert.NoError(t, p.Bind(&cfg))
+	assert.Equal(t, uint16(20), cfg.MaxConns)
+	assert.Equal(t, []int{80, 443}, cfg.Ports)
+	assert.Equal(t, 6432, cfg.DB.Port)
+}
+
+func TestDynamicParams_BindReportsAllErrors(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--max-conns=70000", "--ports=80,x", "--cache.timeout=1m"})
+	var cfg bindConfig
+	err := p.Bind(&cfg)
+
+	var errs dp.ParamErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		keys := make([]string, 0, len(errs))
+		for _, e := range errs {
+			keys = append(keys, e.Key)
+		}
+		assert.Equal(t, []string{"name", "max-conns", "ports", "db.timeout"}, keys)
+		assert.Equal(t, "MaxConns", errs[1].Field)
+		assert.Equal(t, dp.ErrRequired, errs[0].Err.Error())
+	}
+
+	assert.Error(t, p.Bind(cfg))
+}
+
+func TestDynamicParams_BindTextTypes(t *testing.T) {
+	var cfg struct {
+		Addr     net.IP  `param:"addr"`
+		Fallback *net.IP `param:"fallback"`
+	}
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--addr=10.0.0.1", "--fallback=::1"})
+	assert.NoError(t, p.Bind(&cfg))
+	assert.Equal(t, "10.0.0.1", cfg.Addr.String())
+	if assert.NotNil(t, cfg.Fallback) {
+		assert.Equal(t, "::1", cfg.Fallback.String())
+	}
+
+	p.Set("addr", "not-an-ip")
+	assert.Error(t, p.Bind(&cfg))
+}
+
+func TestDynamicParams_SetFromStruct(t *testing.T) {
+	ratio := 0.5
+	defaults := bindConfig{
+		Name:     "billing",
+		MaxConns: 10,
+		Ratio:    &ratio,
+		Origins:  []string{"a.com"},
+		DB:       bindDBConfig{Host: "db", Port: 5432, Timeout: time.Second},
+		Ignored:  "x",
+	}
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	assert.NoError(t, p.SetFromStruct(&defaults))
+
+	assert.Equal(t, "billing", p.QGetString("name"))
+	assert.Equal(t, 0.5, p.Get("ratio"))
+	assert.Equal(t, time.Second, *p.QGetTimeDuration("db.timeout"))
+	assert.False(t, p.Has("cache.host"))
+	assert.False(t, p.Has("ignored"))
+	assert.Len(t, p.Scan(`^db\.`), 3)
+
+	// Bind allocates the nil Cache, and reports its required timeout
+	var cfg bindConfig
+	err := p.Bind(&cfg)
+	var errs dp.ParamErrors
+	if assert.True(t, errors.As(err, &errs)) && assert.Len(t, errs, 1) {
+		assert.Equal(t, "cache.timeout", errs[0].Key)
+	}
+	defaults.Ignored = ""
+	defaults.Cache = &bindDBConfig{Host: "localhost", Port: 5432}
+	assert.Equal(t, defaults, cfg)
+
+	assert.Error(t, p.SetFromStruct("not a struct"))
+}
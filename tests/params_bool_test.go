@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicParams_GetStringAsBoolVocabulary(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--debug=Yes", "--cache=off", "--tls=ENABLED",
+		"--verbose=y", "--strict=F", "--color=maybe"})
+
+	assert.True(t, p.QGetStringAsBool("debug"))
+	assert.False(t, p.QGetStringAsBool("cache"))
+	assert.True(t, p.QGetStringAsBool("tls"))
+	assert.True(t, p.QGetStringAsBool("verbose"))
+
+	v, err := p.GetStringAsBool("strict")
+	assert.NoError(t, err)
+	assert.False(t, v)
+	_, err = p.GetStringAsBool("color")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+}
+
+func TestDynamicParams_GetStringAsBoolFromEnvAndFiles(t *testing.T) {
+	env := dp.NewDynamicParams(dp.SrcNameEnv, &dp.EnvOptions{Prefix: "APP_", Environ: []string{"APP_DEBUG=on"}})
+	assert.True(t, env.QGetStringAsBool("debug"))
+
+	file := dp.NewDynamicParams(dp.SrcNameJSON, `{"debug": true, "cache": "No"}`)
+	assert.True(t, file.QGetStringAsBool("debug"))
+	v, err := file.GetStringAsBool("cache")
+	assert.NoError(t, err)
+	assert.False(t, v)
+}
+
+func TestDynamicParams_SetBoolValues(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("a", "si").Set("b", "yes")
+
+	bools := dp.DefaultBoolValues()
+	bools.True = append(bools.True, "si")
+	p.SetBoolValues(bools)
+	assert.True(t, p.QGetStringAsBool("a"))
+	assert.True(t, p.QGetStringAsBool("b"))
+
+	p.SetBoolValues(dp.BoolValues{True: []string{"si"}, False: []string{"no"}})
+	_, err := p.GetStringAsBool("b")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+}
+
+func TestDynamicParams_BoolValuesInBindAndSchema(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--debug=si", "--flags=cache=si,tls=no"})
+	p.SetBoolValues(dp.BoolValues{True: []string{"si"}, False: []string{"no"}})
+
+	var cfg struct {
+		Debug bool `param:"debug"`
+	}
+	assert.NoError(t, p.Bind(&cfg))
+	assert.True(t, cfg.Debug)
+
+	schema := dp.NewSchema(&dp.ParamSpec{Key: "debug", Type: dp.TypeBool})
+	assert.NoError(t, p.Validate(schema))
+
+	var flags map[string]bool
+	assert.NoError(t, p.GetAsMapOf("flags", &flags))
+	assert.Equal(t, map[string]bool{"cache": true, "tls": false}, flags)
+
+	p.SetBoolValues(dp.DefaultBoolValues())
+	assert.Error(t, p.Bind(&cfg))
+	assert.Error(t, p.Validate(schema))
+}
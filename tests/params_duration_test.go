@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicParams_GetStringAsTimeDurationExtended(t *testing.T) {
+	day := 24 * time.Hour
+	cases := map[string]time.Duration{
+		"1h30m":     90 * time.Minute,
+		"7d":        7 * day,
+		"2w":        14 * day,
+		"2w3d12h":   17*day + 12*time.Hour,
+		"1.5d":      36 * time.Hour,
+		"-1d":       -day,
+		"PT15M":     15 * time.Minute,
+		"P1DT2H":    day + 2*time.Hour,
+		"P2W":       14 * day,
+		"PT1.5S":    1500 * time.Millisecond,
+		"PT0,5H":    30 * time.Minute,
+		"-P1D":      -day,
+		"pt1h30m5s": 90*time.Minute + 5*time.Second,
+	}
+	for in, want := range cases {
+		p := dp.NewDynamicParams(dp.SrcNameInternal)
+		p.Set("d", in)
+		d, err := p.GetStringAsTimeDuration("d")
+		if assert.NoError(t, err, in) {
+			assert.Equal(t, want, *d, in)
+		}
+	}
+
+	for _, in := range []string{"P1Y", "P1M", "PT", "P", "P1DT", "P1H", "1x", "7dd", "30"} {
+		p := dp.NewDynamicParams(dp.SrcNameInternal)
+		p.Set("d", in)
+		_, err := p.GetStringAsTimeDuration("d")
+		assert.EqualError(t, err, dp.ErrCnvFailed, in)
+	}
+}
+
+func TestDynamicParams_SetDurationUnit(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"ttl": 30, "retention": "7d", "big": 9223372036854775807}`)
+	p.Set("timeout", 250).Set("native", time.Minute).Set("bare", "45")
+
+	_, err := p.GetAsTimeDuration("ttl")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+
+	p.SetDurationUnit(time.Second)
+	assert.Equal(t, 30*time.Second, *p.QGetTimeDuration("ttl"))
+	assert.Equal(t, time.Minute, *p.QGetTimeDuration("native"))
+	assert.Equal(t, 45*time.Second, *p.QGetStringAsTimeDuration("bare"))
+	assert.Equal(t, 7*24*time.Hour, *p.QGetStringAsTimeDuration("retention"))
+	_, err = p.GetAsTimeDuration("big")
+	assert.EqualError(t, err, dp.ErrOverflow)
+
+	p.SetDurationUnit(time.Millisecond)
+	assert.Equal(t, 250*time.Millisecond, *p.QGetTimeDuration("timeout"))
+}
+
+func TestDynamicParams_DurationUnitInSchemaAndBind(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"ttl": 30}`)
+	p.Set("bare", "45")
+	schema := dp.NewSchema(
+		&dp.ParamSpec{Key: "ttl", Type: dp.TypeDuration, Max: dp.Bound(float64(time.Minute))},
+		&dp.ParamSpec{Key: "bare", Type: dp.TypeDuration},
+	)
+	assert.Error(t, p.Validate(schema))
+
+	p.SetDurationUnit(time.Second)
+	assert.NoError(t, p.Validate(schema))
+
+	var cfg struct {
+		TTL  time.Duration `param:"ttl"`
+		Bare time.Duration `param:"bare"`
+	}
+	assert.NoError(t, p.Bind(&cfg))
+	assert.Equal(t, 30*time.Second, cfg.TTL)
+	assert.Equal(t, 45*time.Second, cfg.Bare)
+}
+
+func TestDynamicParams_ExtendedDurationsInListsAndBind(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--windows=1d,PT30M", "--retention=2w"})
+	assert.Equal(t, []time.Duration{24 * time.Hour, 30 * time.Minute}, p.QGetDurationSlice("windows"))
+
+	var cfg struct {
+		Retention time.Duration
+	}
+	assert.NoError(t, p.Bind(&cfg))
+	assert.Equal(t, 14*24*time.Hour, cfg.Retention)
+}
+
+func TestDynamicParams_DurationUnitInSlices(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"windows": [30, "1m"]}`)
+	p.Set("ttls", "30,60")
+	_, err := p.GetAsDurationSlice("ttls")
+	assert.EqualError(t, err, "ttls[0]: "+dp.ErrCnvFailed)
+
+	p.SetDurationUnit(time.Second)
+	assert.Equal(t, []time.Duration{30 * time.Second, time.Minute}, p.QGetDurationSlice("ttls"))
+	assert.Equal(t, []time.Duration{30 * time.Second, time.Minute}, p.QGetDurationSlice("windows"))
+
+	var cfg struct {
+		TTLs []time.Duration `param:"ttls"`
+	}
+	assert.NoError(t, p.Bind(&cfg))
+	assert.Equal(t, p.QGetDurationSlice("ttls"), cfg.TTLs)
+}
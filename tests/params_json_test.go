@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicParams_MarshalJSONFlat(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("db.host", "db-1").Set("db.port", 5432).Set("timeout", 90*time.Second).Set("debug", true)
+
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"db.host":"db-1","db.port":5432,"debug":true,"timeout":"1m30s"}`, string(b))
+}
+
+func TestDynamicParams_MarshalJSONNested(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal).SetJSONOptions(dp.JSONOptions{Nested: true})
+	p.Set("db.host", "db-1").Set("db.port", 5432).Set("log", "info").Set("log.level", "debug")
+
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"db":{"host":"db-1","port":5432},"log":"info","log.level":"debug"}`, string(b))
+}
+
+func TestDynamicParams_JSONRoundTripWithTypeHints(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal).SetJSONOptions(dp.JSONOptions{Nested: true, TypeHints: true})
+	p.Set("limits.max", int64(1)<<60).Set("timeout", 90*time.Second).Set("ratio", 0.5).Set("name", "svc")
+
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+
+	var restored dp.DynamicParams
+	assert.NoError(t, json.Unmarshal(b, &restored))
+	assert.Equal(t, int64(1)<<60, restored.Get("limits.max"))
+	assert.Equal(t, 90*time.Second, restored.Get("timeout"))
+	assert.Equal(t, 0.5, restored.Get("ratio"))
+	assert.Equal(t, "svc", restored.Get("name"))
+	assert.False(t, restored.Has(dp.JSONTypesKey))
+}
+
+func TestDynamicParams_UnmarshalJSONWithoutHints(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	assert.NoError(t, json.Unmarshal([]byte(`{"db": {"port": 5432}, "name": "svc"}`), p))
+	assert.Equal(t, json.Number("5432"), p.Get("db.port"))
+	assert.Equal(t, 5432, p.QGetInt("db.port"))
+	assert.Equal(t, "svc", p.QGetString("name"))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"timeout": "soon", "$types": {"timeout": "duration"}}`), p))
+	assert.Error(t, json.Unmarshal([]byte(`[1, 2]`), p))
+}
+
+func TestSource_MarshalJSON(t *testing.T) {
+	args := dp.NewSourceArgs([]string{"--port=8080", "--name=svc"})
+	b, err := json.Marshal(args)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"svc","port":"8080"}`, string(b))
+
+	src := dp.NewSourceInternal()
+	assert.NoError(t, json.Unmarshal([]byte(`{"a": {"b": 1}}`), src))
+	assert.Equal(t, json.Number("1"), src.Get("a.b"))
+
+	comp := dp.NewSourceComposite(dp.NewSourceInternal().Add("name", "top"), args)
+	b, err = json.Marshal(comp)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"top","port":"8080"}`, string(b))
+}
+
+func TestDynamicParams_UnmarshalJSONReplaces(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("stale", "x")
+	assert.NoError(t, json.Unmarshal([]byte(`{"name": "svc"}`), p))
+	assert.False(t, p.Has("stale"))
+	assert.Equal(t, int64(1), p.Count())
+
+	args := dp.NewSourceArgs([]string{"--port=8080", "serve", "file.txt"}, &dp.ArgsOptions{Commands: map[string]*dp.ArgsOptions{"serve": nil}})
+	assert.Equal(t, "serve", args.CommandName())
+	assert.NoError(t, json.Unmarshal([]byte(`{"port": "9090"}`), args))
+	assert.Equal(t, "9090", args.Get("port"))
+	assert.Equal(t, 0, args.NArg())
+	assert.Equal(t, "", args.CommandName())
+	assert.Nil(t, args.Command())
+}
+
+func TestDynamicParams_JSONRoundTripComposite(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameComposite, dp.NewSourceArgs([]string{"--port=9090"}),
+		dp.NewSourceInternal().Add("port", "80").Add("host", "localhost"))
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+
+	restored := dp.NewDynamicParams(dp.SrcNameComposite, dp.NewSourceInternal().Add("stale", "x"))
+	assert.NoError(t, json.Unmarshal(b, restored))
+	b2, err := json.Marshal(restored)
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), string(b2))
+	assert.Equal(t, "9090", restored.QGetString("port"))
+
+	assert.Error(t, json.Unmarshal(b, p.Sub("db")))
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicParams_LenientPerCall(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("port", int64(8080)).Set("workers", uint16(4)).Set("retries", float64(3))
+
+	_, err := p.GetAsInt("port")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+
+	l := p.Lenient()
+	assert.Equal(t, 8080, l.QGetInt("port"))
+	assert.Equal(t, int8(4), l.QGetInt8("workers"))
+	assert.Equal(t, uint32(3), l.QGetUint32("retries"))
+	assert.Equal(t, float32(8080), l.QGetFloat32("port"))
+
+	// the parent keeps its setting
+	assert.Equal(t, 0, p.QGetInt("port"))
+}
+
+func TestDynamicParams_LenientPerInstance(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameYAML, "ratio: 0.5\ncount: 12\nbig: 300\nneg: -1\n").SetLenientNumbers(true)
+	assert.Equal(t, 12, p.QGetInt("count"))
+	assert.Equal(t, uint64(12), p.QGetUint64("count"))
+	assert.Equal(t, 0.5, p.QGetFloat64("ratio"))
+
+	_, err := p.GetAsInt("ratio")
+	assert.EqualError(t, err, dp.ErrTruncated)
+	_, err = p.GetAsUint8("big")
+	assert.EqualError(t, err, dp.ErrOverflow)
+	_, err = p.GetAsUint("neg")
+	assert.EqualError(t, err, dp.ErrOverflow)
+	_, err = p.GetAsInt("missing")
+	assert.EqualError(t, err, dp.ErrNotFound)
+}
+
+func TestDynamicParams_LenientRangeAndPrecision(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal).SetLenientNumbers(true)
+	p.Set("maxint", int64(math.MaxInt64)).Set("exact", int64(1)<<53).Set("maxuint", uint64(math.MaxUint64)).
+		Set("huge", 1e300).Set("nan", math.NaN()).Set("num", json.Number("2.0")).Set("text", "12")
+
+	_, err := p.GetAsFloat64("maxint")
+	assert.EqualError(t, err, dp.ErrTruncated)
+	assert.Equal(t, float64(1<<53), p.QGetFloat64("exact"))
+	_, err = p.GetAsInt64("maxuint")
+	assert.EqualError(t, err, dp.ErrOverflow)
+	assert.Equal(t, uint64(math.MaxUint64), p.QGetUint64("maxuint"))
+	_, err = p.GetAsFloat32("huge")
+	assert.EqualError(t, err, dp.ErrOverflow)
+	_, err = p.GetAsInt64("huge")
+	assert.EqualError(t, err, dp.ErrOverflow)
+	_, err = p.GetAsInt("nan")
+	assert.EqualError(t, err, dp.ErrNotFinite)
+	n, err := p.GetAsInt16("num")
+	assert.NoError(t, err)
+	assert.Equal(t, int16(2), n)
+	_, err = p.GetAsInt("text")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+}
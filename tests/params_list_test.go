@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicParams_SliceFromDelimitedString(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--origins=a.com, b.com ,'c.com, d.com'", "--ports=80,0x1BB,8080",
+		"--ratios=0.5, 1e-3", "--flags=yes,off,1", "--timeouts=1s,250ms", "--empty="})
+
+	assert.Equal(t, []string{"a.com", "b.com", "c.com, d.com"}, p.QGetStringSlice("origins"))
+	assert.Equal(t, []int{80, 443, 8080}, p.QGetIntSlice("ports"))
+	assert.Equal(t, []int64{80, 443, 8080}, p.QGetInt64Slice("ports"))
+	assert.Equal(t, []float64{0.5, 0.001}, p.QGetFloat64Slice("ratios"))
+	assert.Equal(t, []bool{true, false, true}, p.QGetBoolSlice("flags"))
+	assert.Equal(t, []time.Duration{time.Second, 250 * time.Millisecond}, p.QGetDurationSlice("timeouts"))
+	assert.Equal(t, []string{}, p.QGetStringSlice("empty"))
+}
+
+func TestDynamicParams_SliceFromNativeAndDecodedLists(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"ports": [80, "443"], "ratios": [1, 0.5], "hosts": ["a", 1]}`)
+	p.Set("native", []int64{1, 2}).Set("durations", []time.Duration{time.Minute})
+
+	assert.Equal(t, []int{80, 443}, p.QGetIntSlice("ports"))
+	assert.Equal(t, []float64{1, 0.5}, p.QGetFloat64Slice("ratios"))
+	assert.Equal(t, []int{1, 2}, p.QGetIntSlice("native"))
+	assert.Equal(t, []time.Duration{time.Minute}, p.QGetDurationSlice("durations"))
+
+	_, err := p.GetAsStringSlice("hosts")
+	var pe *dp.ParamError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, "hosts[1]", pe.Key)
+
+	_, err = p.GetAsIntSlice("ratios")
+	assert.EqualError(t, err, "ratios[1]: "+dp.ErrTruncated)
+	_, err = p.GetAsIntSlice("missing")
+	assert.EqualError(t, err, dp.ErrNotFound)
+}
+
+func TestDynamicParams_SetListOptions(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("hosts", `a.com; "b.com;c.com" ;d.com`).Set("raw", `"a";b`).Set("open", `"a;b`)
+
+	p.SetListOptions(dp.ListOptions{Separator: ";"})
+	assert.Equal(t, []string{"a.com", "b.com;c.com", "d.com"}, p.QGetStringSlice("hosts"))
+	_, err := p.GetAsStringSlice("open")
+	assert.EqualError(t, err, dp.ErrUnterminatedQuote)
+
+	p.SetListOptions(dp.ListOptions{Separator: ";", DisableQuotes: true})
+	assert.Equal(t, []string{`"a"`, "b"}, p.QGetStringSlice("raw"))
+}
+
+func TestDynamicParams_TextAfterQuote(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("bad", `'a'b,c`).Set("spaced", `'a' , b`)
+
+	_, err := p.GetAsStringSlice("bad")
+	assert.EqualError(t, err, dp.ErrTextAfterQuote)
+	assert.Equal(t, []string{"a", "b"}, p.QGetStringSlice("spaced"))
+}
+
+func TestDynamicParams_ListOptionsInBind(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--hosts=a.com;b.com", "--labels=env:prod;team:core"})
+	p.SetListOptions(dp.ListOptions{Separator: ";", KeyValueSeparator: ":"})
+
+	var cfg struct {
+		Hosts  []string          `param:"hosts"`
+		Labels map[string]string `param:"labels"`
+	}
+	assert.NoError(t, p.Bind(&cfg))
+	assert.Equal(t, []string{"a.com", "b.com"}, cfg.Hosts)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "core"}, cfg.Labels)
+
+	schema := dp.NewSchema(&dp.ParamSpec{Key: "hosts", Type: dp.TypeStringSlice, Min: dp.Bound(2)})
+	assert.NoError(t, p.Validate(schema))
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicParams_MapFromEncodedString(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--labels=env=prod, team=core", "--replicas=web=3,worker=0x10",
+		"--quoted='hosts=a,b',team=core", "--broken=env=prod,team", "--bad=web=three"})
+
+	assert.Equal(t, map[string]string{"env": "prod", "team": "core"}, p.QGetStringMap("labels"))
+	assert.Equal(t, map[string]int{"web": 3, "worker": 16}, p.QGetIntMap("replicas"))
+	assert.Equal(t, map[string]string{"hosts": "a,b", "team": "core"}, p.QGetStringMap("quoted"))
+
+	_, err := p.GetAsStringMap("broken")
+	assert.EqualError(t, err, `broken: `+dp.ErrMapEntry+`: "team"`)
+
+	_, err = p.GetAsIntMap("bad")
+	var pe *dp.ParamError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, "bad.web", pe.Key)
+}
+
+func TestDynamicParams_MapFromNativeMaps(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"limits": {"cpu": 2, "mem": "512"}, "tags": {"a": "x", "b": 1}}`)
+	p.Set("yaml", map[interface{}]interface{}{"env": "prod", 1: "one"})
+
+	assert.Equal(t, map[string]int{"cpu": 2, "mem": 512}, p.QGetIntMap("limits"))
+	assert.Equal(t, map[string]string{"env": "prod", "1": "one"}, p.QGetStringMap("yaml"))
+
+	_, err := p.GetAsStringMap("tags")
+	assert.EqualError(t, err, "tags.b: "+dp.ErrCnvFailed)
+	_, err = p.GetAsStringMap("missing")
+	assert.EqualError(t, err, dp.ErrNotFound)
+}
+
+func TestDynamicParams_GetAsMapOf(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("timeouts", "read=5s;write=10s").Set("weights", map[string]interface{}{"a": 0.5, "b": "x"})
+	p.SetListOptions(dp.ListOptions{Separator: ";"})
+
+	var timeouts map[string]time.Duration
+	assert.NoError(t, p.GetAsMapOf("timeouts", &timeouts))
+	assert.Equal(t, map[string]time.Duration{"read": 5 * time.Second, "write": 10 * time.Second}, timeouts)
+
+	// "a" converts but "b" does not, so the map is left as it was
+	weights := map[string]float64{"c": 1}
+	err := p.GetAsMapOf("weights", &weights)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "weights.b")
+	assert.Equal(t, map[string]float64{"c": 1}, weights)
+
+	var unset map[string]float64
+	assert.Error(t, p.GetAsMapOf("weights", &unset))
+	assert.Nil(t, unset)
+
+	timeouts["idle"] = time.Minute
+	assert.NoError(t, p.GetAsMapOf("timeouts", &timeouts))
+	assert.Len(t, timeouts, 3)
+
+	assert.Error(t, p.GetAsMapOf("timeouts", timeouts))
+}
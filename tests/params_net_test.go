@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicParams_GetAsURL(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--api=https://api.example.com:8443/v1", "--plain=http://a.com",
+		"--relative=/v1/users", "--bad=http://[::1"})
+
+	u, err := p.GetAsURL("api", "https")
+	assert.NoError(t, err)
+	assert.Equal(t, "api.example.com", u.Hostname())
+	assert.Equal(t, "8443", u.Port())
+	assert.NotNil(t, p.QGetURL("plain"))
+
+	_, err = p.GetAsURL("plain", "https", "grpc")
+	assert.EqualError(t, err, dp.ErrSchemeNotAllowed)
+	_, err = p.GetAsURL("relative")
+	assert.EqualError(t, err, dp.ErrSchemeNotAllowed)
+	_, err = p.GetAsURL("bad")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+}
+
+func TestDynamicParams_GetAsURLRequiresHost(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("host-port", "localhost:8080").Set("no-host", "https://").Set("mail", "mailto:ops@example.com")
+
+	_, err := p.GetAsURL("host-port")
+	assert.EqualError(t, err, dp.ErrURLWithoutHost)
+	_, err = p.GetAsURL("no-host")
+	assert.EqualError(t, err, dp.ErrURLWithoutHost)
+	u, err := p.GetAsURL("mail", "mailto")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ops@example.com", u.Opaque)
+	}
+
+	var cfg struct {
+		API *url.URL `param:"host-port"`
+	}
+	assert.Error(t, p.Bind(&cfg))
+}
+
+func TestDynamicParams_GetAsIPAndIPNet(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"bind": "10.0.0.5", "v6": "::1", "net": "10.0.0.0/8",
+		"allow": ["192.168.1.0/24", "172.16.0.1"], "allow-str": "10.0.0.0/8, 2001:db8::/32", "broken": "10.0.0.0/8,10.0.0.300"}`)
+
+	assert.Equal(t, net.ParseIP("10.0.0.5"), p.QGetIP("bind"))
+	assert.Equal(t, net.IPv6loopback, p.QGetIP("v6"))
+	_, err := p.GetAsIP("net")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+
+	ipNet := p.QGetIPNet("net")
+	assert.True(t, ipNet.Contains(net.ParseIP("10.1.2.3")))
+	single := p.QGetIPNet("bind")
+	assert.Equal(t, "10.0.0.5/32", single.String())
+
+	allow := p.QGetIPNetSlice("allow")
+	if assert.Len(t, allow, 2) {
+		assert.True(t, allow[0].Contains(net.ParseIP("192.168.1.20")))
+		assert.Equal(t, "172.16.0.1/32", allow[1].String())
+	}
+	assert.Len(t, p.QGetIPNetSlice("allow-str"), 2)
+	_, err = p.GetAsIPNetSlice("broken")
+	assert.EqualError(t, err, "broken[1]: "+dp.ErrCnvFailed)
+}
+
+func TestDynamicParams_GetAsHostPortAndTCPAddr(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--db=db.internal:5432", "--listen=:8080", "--v6=[fe80::1%eth0]:443",
+		"--wide=host:70000", "--noport=db.internal", "--ip=127.0.0.1:9000"})
+
+	host, port, err := p.GetAsHostPort("db")
+	assert.NoError(t, err)
+	assert.Equal(t, "db.internal", host)
+	assert.Equal(t, 5432, port)
+	_, _, err = p.GetAsHostPort("wide")
+	assert.EqualError(t, err, dp.ErrPortRange)
+	_, _, err = p.GetAsHostPort("noport")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+
+	assert.Equal(t, &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 9000}, p.QGetTCPAddr("ip"))
+	assert.Equal(t, &net.TCPAddr{Port: 8080}, p.QGetTCPAddr("listen"))
+	assert.Equal(t, &net.TCPAddr{IP: net.ParseIP("fe80::1"), Port: 443, Zone: "eth0"}, p.QGetTCPAddr("v6"))
+	_, err = p.GetAsTCPAddr("db")
+	assert.EqualError(t, err, dp.ErrNotIPAddress)
+}
+
+type bindNetConfig struct {
+	API      *url.URL    `param:"api"`
+	Callback url.URL     `param:"callback"`
+	Bind     net.IP      `param:"bind"`
+	Allow    []net.IPNet `param:"allow"`
+	Trusted  *net.IPNet  `param:"trusted"`
+	Listen   *net.TCPAddr
+}
+
+func TestDynamicParams_BindNetTypes(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--api=https://api.example.com/v1",
+		"--callback=http://localhost/cb", "--bind=10.0.0.1", "--allow=10.0.0.0/8,192.168.1.1",
+		"--trusted=172.16.0.0/12", "--listen=:8080"})
+
+	var cfg bindNetConfig
+	assert.NoError(t, p.Bind(&cfg))
+	if assert.NotNil(t, cfg.API) {
+		assert.Equal(t, "api.example.com", cfg.API.Host)
+	}
+	assert.Equal(t, "/cb", cfg.Callback.Path)
+	assert.Equal(t, "10.0.0.1", cfg.Bind.String())
+	if assert.Len(t, cfg.Allow, 2) {
+		assert.Equal(t, "192.168.1.1/32", cfg.Allow[1].String())
+	}
+	if assert.NotNil(t, cfg.Trusted) {
+		assert.Equal(t, "172.16.0.0/12", cfg.Trusted.String())
+	}
+	if assert.NotNil(t, cfg.Listen) {
+		assert.Equal(t, 8080, cfg.Listen.Port)
+	}
+
+	// SetFromStruct keeps them as values, so they round trip
+	q := dp.NewDynamicParams(dp.SrcNameInternal)
+	assert.NoError(t, q.SetFromStruct(cfg))
+	assert.Len(t, q.Scan(`^api`), 1)
+	var again bindNetConfig
+	assert.NoError(t, q.Bind(&again))
+	assert.Equal(t, cfg, again)
+
+	p.Set("bind", "example.com").Set("api", "/relative")
+	err := p.Bind(&cfg)
+	var errs dp.ParamErrors
+	if assert.True(t, errors.As(err, &errs)) && assert.Len(t, errs, 2) {
+		assert.Equal(t, dp.ErrSchemeNotAllowed, errs[0].Err.Error())
+		assert.Equal(t, "bind", errs[1].Key)
+	}
+}
+
+func TestDynamicParams_ValidateNetTypes(t *testing.T) {
+	schema := dp.NewSchema(
+		&dp.ParamSpec{Key: "api", Type: dp.TypeURL, Pattern: `^https://`},
+		&dp.ParamSpec{Key: "bind", Type: dp.TypeIP},
+		&dp.ParamSpec{Key: "trusted", Type: dp.TypeCIDR},
+		&dp.ParamSpec{Key: "listen", Type: dp.TypeTCPAddr},
+	)
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--api=https://a.com", "--bind=::1",
+		"--trusted=10.0.0.0/8", "--listen=127.0.0.1:80"})
+	assert.NoError(t, p.Validate(schema))
+
+	p.Set("api", "http://a.com").Set("bind", "x").Set("trusted", "10.0.0.0/99").Set("listen", "db:80")
+	var errs dp.ParamErrors
+	if assert.True(t, errors.As(p.Validate(schema), &errs)) {
+		assert.Len(t, errs, 4)
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicParams_GetAsUintAndFloat(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("bytes", uint64(1)<<40).Set("port", uint16(8080)).Set("ratio", 0.25).Set("rate", float32(1.5))
+
+	assert.Equal(t, uint64(1)<<40, p.QGetUint64("bytes"))
+	assert.Equal(t, uint16(8080), p.QGetUint16("port"))
+	assert.Equal(t, 0.25, p.QGetFloat64("ratio"))
+	assert.Equal(t, float32(1.5), p.QGetFloat32("rate"))
+
+	_, err := p.GetAsUint32("bytes")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+	_, err = p.GetAsUint("missing")
+	assert.EqualError(t, err, dp.ErrNotFound)
+}
+
+func TestDynamicParams_GetAsFloatRejectsNonFinite(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("nan", math.NaN()).Set("inf", float32(math.Inf(1)))
+
+	_, err := p.GetAsFloat64("nan")
+	assert.EqualError(t, err, dp.ErrNotFinite)
+	_, err = p.GetAsFloat32("inf")
+	assert.EqualError(t, err, dp.ErrNotFinite)
+}
+
+func TestDynamicParams_GetAsUintFromJSON(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"small": 200, "big": 300, "neg": -1, "max": 18446744073709551615, "f": 1e40}`)
+
+	assert.Equal(t, uint8(200), p.QGetUint8("small"))
+	assert.Equal(t, uint64(math.MaxUint64), p.QGetUint64("max"))
+	_, err := p.GetAsUint8("big")
+	assert.EqualError(t, err, dp.ErrOverflow)
+	_, err = p.GetAsUint("neg")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+	_, err = p.GetAsFloat32("f")
+	assert.EqualError(t, err, dp.ErrOverflow)
+	assert.Equal(t, 1e40, p.QGetFloat64("f"))
+}
+
+func TestDynamicParams_GetStringAsUintAndFloat(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--port=8080", "--ratio=0.25", "--neg=-5",
+		"--nan=NaN", "--inf=+Inf", "--huge=1e400", "--wide=70000"})
+
+	assert.Equal(t, uint16(8080), p.QGetStringAsUint16("port"))
+	assert.Equal(t, uintptr(8080), p.QGetStringAsUintptr("port"))
+	assert.Equal(t, 0.25, p.QGetStringAsFloat64("ratio"))
+	assert.Equal(t, float32(0.25), p.QGetStringAsFloat32("ratio"))
+
+	_, err := p.GetStringAsUint("neg")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+	_, err = p.GetStringAsUint16("wide")
+	assert.EqualError(t, err, dp.ErrOverflow)
+	_, err = p.GetStringAsFloat64("nan")
+	assert.EqualError(t, err, dp.ErrNotFinite)
+	_, err = p.GetStringAsFloat64("inf")
+	assert.EqualError(t, err, dp.ErrNotFinite)
+	_, err = p.GetStringAsFloat64("huge")
+	assert.EqualError(t, err, dp.ErrOverflow)
+}
+
+func TestDynamicParams_GetStringAsIntLiterals(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--port=0", "--offset=-5", "--plus=+7", "--hex=0x1F",
+		"--octal=0o755", "--bin=0b101", "--big=1_000_000", "--legacy=0123", "--wide=300", "--bad=12a", "--under=1__0"})
+
+	assert.Equal(t, 0, p.QGetStringAsInt("port"))
+	assert.Equal(t, -5, p.QGetStringAsInt("offset"))
+	assert.Equal(t, int8(7), p.QGetStringAsInt8("plus"))
+	assert.Equal(t, int32(31), p.QGetStringAsInt32("hex"))
+	assert.Equal(t, int16(493), p.QGetStringAsInt16("octal"))
+	assert.Equal(t, int64(5), p.QGetStringAsInt64("bin"))
+	assert.Equal(t, 1000000, p.QGetStringAsInt("big"))
+	assert.Equal(t, uint8(7), p.QGetStringAsUint8("plus"))
+	assert.Equal(t, uint16(31), p.QGetStringAsUint16("hex"))
+
+	_, err := p.GetStringAsInt("legacy")
+	assert.EqualError(t, err, dp.ErrLeadingZero)
+	_, err = p.GetStringAsInt8("wide")
+	assert.EqualError(t, err, dp.ErrOverflow)
+	_, err = p.GetStringAsInt("bad")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+	_, err = p.GetStringAsInt("under")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+}
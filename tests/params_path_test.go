@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+type pathServer struct {
+	Host     string
+	HTTPPort int `param:"port"`
+}
+
+func TestDynamicParams_PathIntoMapsAndSlices(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("db", map[string]interface{}{
+		"primary": map[string]interface{}{"host": "db-1", "port": 5432},
+		"replicas": []interface{}{
+			map[string]interface{}{"host": "db-2"},
+			map[string]interface{}{"host": "db-3"},
+		},
+	})
+	p.Set("db.primary.user", "flat-wins")
+
+	assert.Equal(t, "db-1", p.Get("db.primary.host"))
+	assert.Equal(t, 5432, p.QGetInt("db.primary.port"))
+	assert.Equal(t, "db-3", p.QGetString("db.replicas[1].host"))
+	assert.Equal(t, "flat-wins", p.QGetString("db.primary.user"))
+	assert.True(t, p.Has("db.replicas[0]"))
+	assert.False(t, p.Has("db.replicas[2].host"))
+	assert.False(t, p.Has("db.primary.host.name"))
+	assert.Nil(t, p.Get("db.missing"))
+
+	_, err := p.GetAsString("db.replicas[5].host")
+	assert.Equal(t, dp.ErrNotFound, err.Error())
+}
+
+func TestDynamicParams_PathIntoStructs(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("servers", []*pathServer{{Host: "a", HTTPPort: 80}, {Host: "b", HTTPPort: 8080}})
+
+	assert.Equal(t, "b", p.QGetString("servers[1].Host"))
+	assert.Equal(t, "b", p.QGetString("servers[1].host"))
+	assert.Equal(t, 8080, p.QGetInt("servers[1].port"))
+	assert.Equal(t, 80, p.QGetInt("servers[0].http-port"))
+}
+
+func TestDynamicParams_PathIntoJSONLists(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, "testdata/params.json")
+	port, err := p.GetAsInt("servers[1].port")
+	assert.NoError(t, err)
+	assert.Equal(t, 9002, port)
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServiceSchema() *dp.Schema {
+	return dp.NewSchema(
+		&dp.ParamSpec{Key: "port", Type: dp.TypeInt, Required: true, Min: dp.Bound(1), Max: dp.Bound(65535)},
+		&dp.ParamSpec{Key: "env", Type: dp.TypeString, Enum: []interface{}{"dev", "staging", "prod"}},
+		&dp.ParamSpec{Key: "name", Type: dp.TypeString, Pattern: `^[a-z-]+$`, Max: dp.Bound(16)},
+		&dp.ParamSpec{Key: "timeout", Type: dp.TypeDuration, Default: "5s", Required: true},
+		&dp.ParamSpec{Key: "origins", Type: dp.TypeStringSlice, Validate: func(v interface{}) error {
+			for _, o := range v.([]string) {
+				if !strings.HasPrefix(o, "https://") {
+					return errors.New("origins must use https")
+				}
+			}
+			return nil
+		}},
+		&dp.ParamSpec{Key: "db.host", Type: dp.TypeString, Required: true, Description: "database host"},
+	)
+}
+
+func TestDynamicParams_ValidateValid(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--port=8080", "--env=prod", "--name=billing",
+		"--origins=https://a.com,https://b.com", "--db.host=localhost"})
+	assert.NoError(t, p.Validate(newServiceSchema()))
+}
+
+func TestDynamicParams_ValidateReportsAll(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"port": 70000, "env": "qa", "name": "Billing",
+		"origins": ["http://a.com"], "timeout": "soon"}`)
+	err := p.Validate(newServiceSchema())
+
+	var errs dp.ParamErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		got := map[string]string{}
+		for _, e := range errs {
+			got[e.Key] = e.Err.Error()
+		}
+		assert.Len(t, errs, 6)
+		assert.Contains(t, got["port"], dp.ErrAboveMax)
+		assert.Contains(t, got["env"], dp.ErrNotInEnum)
+		assert.Contains(t, got["name"], dp.ErrPatternMismatch)
+		assert.Contains(t, got["timeout"], dp.ErrTypeMismatch)
+		assert.Equal(t, "origins must use https", got["origins"])
+		assert.Equal(t, dp.ErrRequired, got["db.host"])
+	}
+}
+
+func TestDynamicParams_ApplyDefaults(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("port", 80)
+	p.ApplyDefaults(newServiceSchema())
+	d, err := p.GetStringAsTimeDuration("timeout")
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, *d)
+	assert.Equal(t, 80, p.QGetInt("port"))
+}
+
+func TestNewSchemaE_InvalidSpecs(t *testing.T) {
+	schema, err := dp.NewSchemaE(
+		&dp.ParamSpec{Key: "name", Type: dp.TypeString, Pattern: `^[a-z+$`},
+		&dp.ParamSpec{Key: "port", Type: dp.TypeInt, Default: "eighty"},
+		&dp.ParamSpec{Key: "retries", Type: dp.TypeInt, Default: 10, Max: dp.Bound(5)},
+		&dp.ParamSpec{Key: "env", Type: dp.TypeString, Default: "dev", Pattern: `^[a-z]+$`},
+		&dp.ParamSpec{Key: "workers", Type: "integer"},
+		&dp.ParamSpec{Key: "extra"},
+	)
+	var errs dp.ParamErrors
+	if assert.True(t, errors.As(err, &errs)) && assert.Len(t, errs, 4) {
+		assert.Equal(t, "workers", errs[3].Key)
+		assert.Contains(t, errs[3].Err.Error(), "unknown type")
+		assert.Equal(t, "name", errs[0].Key)
+		assert.Contains(t, errs[0].Err.Error(), "invalid pattern")
+		assert.Equal(t, "port", errs[1].Key)
+		assert.Contains(t, errs[1].Err.Error(), dp.ErrTypeMismatch)
+		assert.Contains(t, errs[2].Err.Error(), dp.ErrAboveMax)
+	}
+
+	// Validate reports the invalid specs too
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("env", "prod")
+	assert.Error(t, p.Validate(schema))
+	assert.NoError(t, p.Validate(dp.NewSchema(schema.Specs()[3])))
+	assert.NoError(t, newServiceSchema().Err())
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicParams_SubStripsPrefix(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--header-content-type='application/json'",
+		"--header-origin='localhost'", "--header-content-length='456'", "--unrelated-content-length='456'"})
+
+	h := p.Sub("header")
+	assert.Equal(t, int64(3), h.Count())
+	assert.Equal(t, "application/json", h.QGetQuotedString("content-type"))
+	assert.True(t, h.Has("origin"))
+	assert.False(t, h.Has("unrelated-content-length"))
+	assert.Len(t, h.Scan(`^content-`), 2)
+
+	keys := map[string]bool{}
+	h.Iterate(func(key string, value interface{}) {
+		keys[key] = true
+	})
+	assert.Equal(t, map[string]bool{"content-type": true, "origin": true, "content-length": true}, keys)
+}
+
+func TestDynamicParams_SubSharesStorageAndLock(t *testing.T) {
+	mx := &sync.RWMutex{}
+	p := dp.NewDynamicParams(dp.SrcNameJSON, mx, `{"db": {"host": "db-1", "pool": {"size": 5}}}`)
+	db := p.Sub("db")
+	assert.Equal(t, mx, db.Mx)
+	assert.Equal(t, 5, db.QGetInt("pool.size"))
+	assert.Equal(t, 5, db.Sub("pool").QGetInt("size"))
+
+	db.Set("user", "admin")
+	assert.Equal(t, "admin", p.QGetString("db.user"))
+
+	ro := p.SubReadOnly("db")
+	ro.Set("password", "secret")
+	assert.False(t, p.Has("db.password"))
+	assert.Equal(t, "db-1", ro.QGetString("host"))
+}
+
+func TestDynamicParams_SubLiteralPrefix(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("header-origin", "a").Set("header.origin", "b")
+	assert.Equal(t, "a", p.Sub("header-").QGetString("origin"))
+	assert.Equal(t, int64(1), p.Sub("header-").Count())
+}
+
+func TestDynamicParams_SubBothSeparators(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("header-x", "dash").Set("header.x", "dot").Set("header-y", "only")
+
+	h := p.Sub("header")
+	assert.Equal(t, int64(2), h.Count())
+	assert.Equal(t, "dot", h.QGetString("x"))
+
+	seen := map[string]interface{}{}
+	h.Iterate(func(key string, value interface{}) {
+		_, dup := seen[key]
+		assert.False(t, dup, key)
+		seen[key] = value
+	})
+	assert.Equal(t, map[string]interface{}{"x": "dot", "y": "only"}, seen)
+	assert.Equal(t, map[string]interface{}{"x": "dot"}, h.Scan(`^x$`))
+}
+
+func TestDynamicParams_SubOverCompoundValue(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("db", map[string]interface{}{"host": "db-1", "port": 5432, "pool": map[string]interface{}{"max": 10}})
+	p.Set("db.port", 6432)
+
+	db := p.Sub("db")
+	assert.Equal(t, "db-1", db.QGetString("host"))
+	assert.Equal(t, int64(3), db.Count())
+
+	seen := map[string]interface{}{}
+	db.Iterate(func(key string, value interface{}) {
+		seen[key] = value
+	})
+	assert.Equal(t, map[string]interface{}{"host": "db-1", "port": 6432, "pool.max": 10}, seen)
+	assert.Equal(t, 6432, db.Get("port"))
+	assert.Len(t, db.Scan(`^pool\.`), 1)
+}
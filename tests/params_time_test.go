@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicParams_GetAsTime(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"cutover": "2024-03-01T02:00:00+01:00", "day": "2024-03-01",
+		"unix": 1709254800, "nanos": "2024-03-01T01:00:00.5Z", "bad": "tomorrow"}`)
+	p.Set("native", time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC)).Set("millis", int64(1709254800500))
+
+	cutover, err := p.GetAsTime("cutover")
+	assert.NoError(t, err)
+	assert.True(t, cutover.Equal(time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), p.QGetTime("day"))
+	assert.True(t, p.QGetTime("unix").Equal(cutover))
+	assert.True(t, p.QGetTime("native").Equal(cutover))
+	assert.Equal(t, 500*time.Millisecond, p.QGetTime("nanos").Sub(cutover))
+
+	_, err = p.GetAsTime("bad")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+	_, err = p.GetAsTime("missing")
+	assert.EqualError(t, err, dp.ErrNotFound)
+
+	p.SetTimeOptions(dp.TimeOptions{UnixUnit: time.Millisecond})
+	assert.Equal(t, 500*time.Millisecond, p.QGetTime("millis").Sub(cutover))
+}
+
+func TestDynamicParams_GetAsTimeLayoutsAndLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--window=01/03/2024 22:30", "--zone=Europe/Berlin",
+		"--late=2024-03-01T23:30:00Z", "--zone-bad=Mars/Olympus"})
+	p.SetTimeOptions(dp.TimeOptions{Layouts: []string{"02/01/2006 15:04"}, Location: berlin})
+
+	assert.Equal(t, time.Date(2024, 3, 1, 22, 30, 0, 0, berlin), p.QGetTime("window"))
+	assert.Equal(t, berlin, p.QGetLocation("zone"))
+	_, err = p.GetAsLocation("zone-bad")
+	assert.EqualError(t, err, dp.ErrCnvFailed)
+
+	// 23:30 UTC is already the next day in Berlin, RFC 3339 is no longer a layout
+	_, err = p.GetAsDate("late")
+	assert.Error(t, err)
+	p.SetTimeOptions(dp.TimeOptions{Location: berlin})
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, berlin), p.QGetDate("late"))
+}
+
+func TestDynamicParams_BindTime(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--starts-at=2024-03-01"})
+	var cfg struct {
+		StartsAt time.Time
+	}
+	assert.NoError(t, p.Bind(&cfg))
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), cfg.StartsAt)
+}
+
+func TestDynamicParams_BindTimeWithOptions(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--at=01/03/2024 22:30", "--since=1709330400000"})
+	p.SetTimeOptions(dp.TimeOptions{Layouts: []string{"02/01/2006 15:04"}, UnixUnit: time.Millisecond})
+
+	var cfg struct {
+		At    time.Time `param:"at"`
+		Since time.Time `param:"since"`
+	}
+	assert.NoError(t, p.Bind(&cfg))
+	assert.Equal(t, p.QGetTime("at"), cfg.At)
+	assert.Equal(t, time.Date(2024, 3, 1, 22, 30, 0, 0, time.UTC), cfg.At)
+	assert.True(t, time.Unix(1709330400, 0).Equal(cfg.Since))
+}
+
+func TestDynamicParams_GetAsLocationBlank(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	p.Set("empty", "").Set("blank", "  ").Set("utc", " UTC ")
+	for _, key := range []string{"empty", "blank"} {
+		_, err := p.GetAsLocation(key)
+		assert.EqualError(t, err, dp.ErrCnvFailed, key)
+	}
+	assert.Equal(t, time.UTC, p.QGetLocation("utc"))
+}
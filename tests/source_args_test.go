@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceArgs_GNUGrammar(t *testing.T) {
+	opts := &dp.ArgsOptions{
+		Aliases:    map[string]string{"v": "verbose", "p": "port", "o": "output"},
+		ValueFlags: []string{"port", "output", "db.host"},
+	}
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"tool", "--port", "8080", "--verbose=false",
+		"-v", "--no-color", "--http2-enabled", "--db.host", "localhost", "--max_conns=10",
+		"-xo", "out.txt", "--", "--ignored=1"}, opts)
+
+	assert.Equal(t, 8080, p.QGetStringAsInt("port"))
+	assert.True(t, p.QGetStringAsBool("verbose"))
+	assert.False(t, p.QGetStringAsBool("color"))
+	assert.True(t, p.Has("color"))
+	assert.True(t, p.QGetStringAsBool("http2-enabled"))
+	assert.Equal(t, "localhost", p.QGetString("db.host"))
+	assert.Equal(t, "10", p.QGetString("max_conns"))
+	assert.True(t, p.QGetStringAsBool("x"))
+	assert.Equal(t, "out.txt", p.QGetString("output"))
+	assert.False(t, p.Has("ignored"))
+}
+
+func TestSourceArgs_ShortValues(t *testing.T) {
+	opts := &dp.ArgsOptions{
+		Aliases:    map[string]string{"p": "port"},
+		ValueFlags: []string{"port"},
+		BoolFlags:  []string{"no-cache"},
+	}
+	src := dp.NewSourceArgs([]string{"-p8080", "--no-cache"}, opts)
+	assert.Equal(t, "8080", src.Get("port"))
+	assert.Equal(t, "true", src.Get("no-cache"))
+
+	src = dp.NewSourceArgs([]string{"-p=9090", "-p"}, opts)
+	assert.Equal(t, "9090", src.Get("port"))
+}
+
+func TestSourceArgs_UndeclaredValueIsNotConsumed(t *testing.T) {
+	src := dp.NewSourceArgs([]string{"--verbose", "deploy", "--key=value"})
+	assert.Equal(t, "true", src.Get("verbose"))
+	assert.Equal(t, "value", src.Get("key"))
+	assert.Equal(t, int64(2), src.Count())
+}
+
+func TestSourceArgs_Positional(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"tool", "copy", "--force", "a.txt", "--", "-b.txt"},
+		&dp.ArgsOptions{SkipProgramName: true})
+	assert.Equal(t, []string{"copy", "a.txt", "-b.txt"}, p.Args())
+	assert.Equal(t, 3, p.NArg())
+	assert.Equal(t, "a.txt", p.Arg(1))
+	assert.Equal(t, "", p.Arg(3))
+	assert.True(t, p.QGetStringAsBool("force"))
+}
+
+func TestSourceArgs_Subcommands(t *testing.T) {
+	opts := &dp.ArgsOptions{
+		Aliases:         map[string]string{"v": "verbose"},
+		SkipProgramName: true,
+		Commands: map[string]*dp.ArgsOptions{
+			"deploy": {
+				Aliases:    map[string]string{"e": "env"},
+				ValueFlags: []string{"env"},
+			},
+		},
+	}
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"tool", "-v", "deploy", "-e", "prod", "service-a"}, opts)
+	assert.Equal(t, "deploy", p.CommandName())
+	assert.Equal(t, 0, p.NArg())
+	assert.False(t, p.Has("env"))
+
+	cmd := p.Command()
+	if assert.NotNil(t, cmd) {
+		assert.Equal(t, "prod", cmd.QGetString("env"))
+		assert.True(t, cmd.QGetStringAsBool("verbose"))
+		assert.Equal(t, []string{"service-a"}, cmd.Args())
+		assert.Equal(t, int64(2), cmd.Count())
+		assert.Nil(t, cmd.Command())
+	}
+}
+
+func TestSourceArgs_SubcommandInComposite(t *testing.T) {
+	opts := &dp.ArgsOptions{Commands: map[string]*dp.ArgsOptions{"deploy": nil}}
+	defaults := dp.NewSourceInternal()
+	defaults.Add("env", "staging").Add("region", "eu")
+	p := dp.NewDynamicParams(dp.SrcNameComposite, dp.NewSourceArgs([]string{"deploy", "--env=prod"}, opts), defaults)
+
+	cmd := p.Command()
+	if assert.NotNil(t, cmd) {
+		assert.Equal(t, "prod", cmd.QGetString("env"))
+		assert.Equal(t, "eu", cmd.QGetString("region"))
+	}
+	assert.Equal(t, "staging", p.QGetString("env"))
+}
+
+func TestSourceArgs_RepeatableAndMapFlags(t *testing.T) {
+	opts := &dp.ArgsOptions{
+		Aliases:         map[string]string{"H": "header", "l": "label"},
+		RepeatableFlags: []string{"header"},
+		MapFlags:        []string{"label"},
+	}
+	p := dp.NewDynamicParams(dp.SrcNameArgs, []string{"--header=Accept: */*", "-H", "Origin: localhost",
+		"--label=env=prod", "-l", "team=core", "--label=empty", "--other=a", "--other=b"}, opts)
+
+	headers, err := p.GetAsStringSlice("header")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Accept: */*", "Origin: localhost"}, headers)
+
+	labels, err := p.GetAsStringMap("label")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "core", "empty": ""}, labels)
+
+	// a flag which is not repeatable keeps its last value,
+	// which reads as a list of one item
+	assert.Equal(t, "b", p.QGetString("other"))
+	assert.Equal(t, []string{"b"}, p.QGetStringSlice("other"))
+}
+
+func TestSourceArgs_StrictReportsAllIssues(t *testing.T) {
+	opts := &dp.ArgsOptions{
+		Strict:     true,
+		Aliases:    map[string]string{"v": "verbose"},
+		ValueFlags: []string{"port", "host"},
+	}
+	_, err := dp.NewSourceArgsE([]string{"-port=80", "--prot=80", "--hots", "x", "-v", "--timeout"}, opts)
+	var ae *dp.ArgsError
+	if assert.True(t, errors.As(err, &ae)) {
+		assert.Equal(t, []dp.ArgsIssue{{Arg: "-port=80", Name: "port", Suggestion: "port"}}, ae.Malformed)
+		assert.Equal(t, []dp.ArgsIssue{
+			{Arg: "--prot=80", Name: "prot", Suggestion: "port"},
+			{Arg: "--hots", Name: "hots", Suggestion: "host"},
+			{Arg: "--timeout", Name: "timeout"},
+		}, ae.Unknown)
+		assert.Contains(t, err.Error(), "--prot=80 (did you mean --port?)")
+	}
+}
+
+func TestSourceArgs_StrictAcceptsDeclaredFlags(t *testing.T) {
+	opts := &dp.ArgsOptions{
+		Strict:     true,
+		ValueFlags: []string{"port"},
+		KnownFlags: []string{"color"},
+		Commands:   map[string]*dp.ArgsOptions{"serve": {BoolFlags: []string{"tls"}}},
+	}
+	src, err := dp.NewSourceArgsE([]string{"--port", "80", "--no-color", "serve", "--tls", "--port=81"}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "81", src.Command().Get("port"))
+
+	_, err = dp.NewSourceArgsE([]string{"--tls", "serve"}, opts)
+	assert.Error(t, err)
+}
+
+func TestSourceArgs_NotStrictIgnoresIssues(t *testing.T) {
+	src, err := dp.NewSourceArgsE([]string{"-port=80", "--prot=80"}, &dp.ArgsOptions{ValueFlags: []string{"port"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "80", src.Get("prot"))
+	assert.False(t, src.Has("port"))
+}
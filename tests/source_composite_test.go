@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLayeredParams() *dp.DynamicParams {
+	defaults := dp.NewSourceInternal()
+	defaults.Add("port", "80").Add("host", "localhost").Add("debug", "false")
+	env := dp.NewSourceEnv(&dp.EnvOptions{Prefix: "APP_", Environ: []string{"APP_PORT=8080", "APP_DEBUG=1"}})
+	args := dp.NewSourceArgs([]string{"--port=9090"})
+	return dp.NewDynamicParams(dp.SrcNameComposite, &sync.RWMutex{}, args, env, defaults)
+}
+
+func TestSourceComposite_Priority(t *testing.T) {
+	p := newLayeredParams()
+	assert.Equal(t, "9090", p.QGetString("port"))
+	assert.Equal(t, "localhost", p.QGetString("host"))
+	assert.True(t, p.QGetStringAsBool("debug"))
+	assert.False(t, p.Has("missing"))
+}
+
+func TestSourceComposite_CountScanIterate(t *testing.T) {
+	p := newLayeredParams()
+	assert.Equal(t, int64(3), p.Count())
+
+	res := p.Scan(`^p`)
+	assert.Equal(t, map[string]interface{}{"port": "9090"}, res)
+
+	seen := map[string]interface{}{}
+	p.Iterate(func(key string, value interface{}) {
+		seen[key] = value
+	})
+	assert.Equal(t, map[string]interface{}{"port": "9090", "host": "localhost", "debug": "1"}, seen)
+}
+
+func TestSourceComposite_SetGoesToFirstSource(t *testing.T) {
+	first := dp.NewSourceInternal()
+	p := dp.NewDynamicParams(dp.SrcNameComposite, first, dp.NewSourceInternal())
+	p.Set("key", "value")
+	assert.True(t, first.Has("key"))
+}
+
+func TestSourceComposite_SkipsFailedSources(t *testing.T) {
+	src := dp.NewSourceComposite(dp.NewSourceArgs([]string{"--a=1"}), dp.NewSourceJSON("missing-config.json"))
+	assert.Len(t, src.Sources(), 1)
+	assert.True(t, src.Has("a"))
+	assert.False(t, src.Has("b"))
+	assert.Nil(t, src.Get("b"))
+}
+
+func TestSourceComposite_FactoryRejectsFailedSources(t *testing.T) {
+	_, err := dp.NewDynamicParamsE(dp.SrcNameComposite, dp.NewSourceInternal(), dp.NewSourceJSON("missing-config.json"))
+	assert.Equal(t, dp.ErrInvalidSourceVars, sourceErrorReason(t, err))
+}
+
+func TestSourceComposite_EmptyKeepsAddedParams(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameComposite)
+	p.Set("a", 1)
+	assert.True(t, p.Has("a"))
+	assert.Equal(t, 1, p.Get("a"))
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceEnv_PrefixAndNestedKeys(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameEnv, &dp.EnvOptions{
+		Prefix:  "APP_",
+		Environ: []string{"APP_DB__HOST=localhost", "APP_HTTP__READ_TIMEOUT=5s", "APP_DEBUG=1", "HOME=/root"},
+	})
+	assert.Equal(t, int64(3), p.Count())
+	assert.False(t, p.Has("home"))
+
+	host, err := p.GetAsString("db.host")
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", host)
+
+	d := p.QGetStringAsTimeDuration("http.read-timeout")
+	assert.NotNil(t, d)
+
+	debug, err := p.GetStringAsBool("debug")
+	assert.NoError(t, err)
+	assert.True(t, debug)
+}
+
+func TestSourceEnv_CustomDelimiter(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameEnv, dp.EnvOptions{
+		Prefix:       "APP_",
+		KeyDelimiter: "-",
+		Environ:      []string{"APP_HEADER__ORIGIN=localhost", "APP_HEADER__CONTENT_TYPE=application/json"},
+	})
+	assert.Len(t, p.Scan(`^header-.+$`), 2)
+	assert.Equal(t, "application/json", p.QGetString("header-content-type"))
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func sourceErrorReason(t *testing.T, err error) string {
+	var se *dp.SourceError
+	if assert.True(t, errors.As(err, &se)) {
+		return se.Reason
+	}
+	return ""
+}
+
+func TestNewDynamicParamsE_UnknownSource(t *testing.T) {
+	p, err := dp.NewDynamicParamsE("source.not-registered")
+	assert.Nil(t, p)
+	assert.Equal(t, dp.ErrUnknownSource, sourceErrorReason(t, err))
+}
+
+func TestNewDynamicParamsE_MissingAndInvalidVars(t *testing.T) {
+	_, err := dp.NewDynamicParamsE(dp.SrcNameArgs, &sync.RWMutex{})
+	assert.Equal(t, dp.ErrMissingSourceVars, sourceErrorReason(t, err))
+
+	_, err = dp.NewDynamicParamsE(dp.SrcNameArgs, "--key=value")
+	assert.Equal(t, dp.ErrInvalidSourceVars, sourceErrorReason(t, err))
+
+	_, err = dp.NewSourceE(dp.SrcNameComposite, dp.NewSourceInternal(), 5)
+	assert.Equal(t, dp.ErrInvalidSourceVars, sourceErrorReason(t, err))
+}
+
+func TestNewDynamicParamsE_InitFailure(t *testing.T) {
+	_, err := dp.NewDynamicParamsE(dp.SrcNameJSON, `{"broken": `)
+	assert.Equal(t, dp.ErrSourceInit, sourceErrorReason(t, err))
+
+	var se *dp.SourceError
+	errors.As(err, &se)
+	assert.Equal(t, dp.SrcNameJSON, se.Source)
+	assert.NotNil(t, errors.Unwrap(err))
+}
+
+func TestNewDynamicParamsE_MissingFile(t *testing.T) {
+	_, err := dp.NewDynamicParamsE(dp.SrcNameJSON, "config/missing.json")
+	assert.Equal(t, dp.ErrSourceInit, sourceErrorReason(t, err))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	_, err = dp.NewDynamicParamsE(dp.SrcNameYAML, "config/missing.yaml")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestNewDynamicParamsE_TrailingJSON(t *testing.T) {
+	_, err := dp.NewDynamicParamsE(dp.SrcNameJSON, `{"a": 1} {"b": 2}`)
+	assert.Equal(t, dp.ErrSourceInit, sourceErrorReason(t, err))
+
+	p := dp.NewDynamicParams(dp.SrcNameInternal)
+	assert.Error(t, p.UnmarshalJSON([]byte(`{"a": 1} trailing`)))
+}
+
+func TestNewDynamicParamsE_Success(t *testing.T) {
+	mx := &sync.RWMutex{}
+	p, err := dp.NewDynamicParamsE(dp.SrcNameArgs, mx, []string{"--key=value"})
+	assert.NoError(t, err)
+	assert.Equal(t, mx, p.Mx)
+	assert.Equal(t, "value", p.QGetString("key"))
+}
+
+func TestNewDynamicParams_UnknownSourceFallsBack(t *testing.T) {
+	p := dp.NewDynamicParams("source.not-registered")
+	p.Set("key", "value")
+	assert.Equal(t, "value", p.QGetString("key"))
+}
+
+// a factory which returns a typed nil source, registered once
+// as RegisterSource() panics on a duplicate name
+func init() {
+	dp.RegisterSource("source.typed-nil", func(vars ...interface{}) (dp.ParamsSource, error) {
+		var src *dp.SourceInternal
+		return src, nil
+	})
+}
+
+func TestNewSourceE_TypedNilSource(t *testing.T) {
+	_, err := dp.NewSourceE("source.typed-nil")
+	assert.Equal(t, dp.ErrSourceInit, sourceErrorReason(t, err))
+}
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceJSON_FromString(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"name": "billing", "port": 8080, "debug": true}`)
+	v, err := p.GetAsString("name")
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", v)
+
+	b, err := p.GetAsBool("debug")
+	assert.NoError(t, err)
+	assert.True(t, b)
+}
+
+func TestSourceJSON_NumbersAsInt(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, strings.NewReader(`{"port": 8080, "big": 9007199254740993, "ratio": 0.5}`))
+	port, err := p.GetAsInt("port")
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, port)
+
+	big, err := p.GetAsInt64("big")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), big)
+
+	_, err = p.GetAsInt8("port")
+	assert.Error(t, err)
+
+	_, err = p.GetAsInt("ratio")
+	assert.Error(t, err)
+}
+
+func TestSourceJSON_NestedFromFile(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, "testdata/params.json")
+	assert.Equal(t, int64(6), p.Count())
+
+	host, err := p.GetAsString("db.host")
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", host)
+
+	size, err := p.GetAsInt("db.pool.size")
+	assert.NoError(t, err)
+	assert.Equal(t, 20, size)
+
+	assert.Len(t, p.Scan(`^db\.`), 2)
+}
+
+func TestSourceJSON_ListsAndNestedKeys(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameJSON, `{"origins": ["a.com", "b.com"], "tags": {"team": "core"}}`)
+	assert.Equal(t, []string{"a.com", "b.com"}, p.QGetStringSlice("origins"))
+	assert.Equal(t, "core", p.QGetString("tags.team"))
+}
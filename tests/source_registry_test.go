@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"testing"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+// sources are registered once per process, as RegisterSource()
+// panics on a duplicate name (e.g. with go test -count=2)
+func init() {
+	dp.RegisterSource("source.test-custom", func(vars ...interface{}) (dp.ParamsSource, error) {
+		src := dp.NewSourceInternal()
+		src.Add("from", vars[0])
+		return src, nil
+	})
+}
+
+func TestRegisterSource_CustomSource(t *testing.T) {
+	assert.True(t, dp.IsSourceRegistered("source.test-custom"))
+	assert.Contains(t, dp.RegisteredSources(), "source.test-custom")
+
+	p := dp.NewDynamicParams("source.test-custom", "factory")
+	assert.Equal(t, "factory", p.QGetString("from"))
+}
+
+func TestRegisterSource_Duplicate(t *testing.T) {
+	assert.Panics(t, func() {
+		dp.RegisterSource(dp.SrcNameInternal, func(vars ...interface{}) (dp.ParamsSource, error) {
+			return nil, nil
+		})
+	})
+}
+
+func TestRegisteredSources_BuiltIns(t *testing.T) {
+	names := dp.RegisteredSources()
+	for _, name := range []string{dp.SrcNameInternal, dp.SrcNameArgs, dp.SrcNameJSON,
+		dp.SrcNameYAML, dp.SrcNameEnv, dp.SrcNameComposite} {
+		assert.Contains(t, names, name)
+	}
+	assert.Nil(t, dp.NewSource("source.unknown"))
+}
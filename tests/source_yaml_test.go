@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	dp "github.com/mostafatalebi/dynamic-params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceYAML_NativeTypes(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameYAML, "port: 8080\ndebug: true\ntimeout: 1m30s\n")
+	port, err := p.GetAsInt64("port")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8080), port)
+
+	debug, err := p.GetAsBool("debug")
+	assert.NoError(t, err)
+	assert.True(t, debug)
+
+	d, err := p.GetStringAsTimeDuration("timeout")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, *d)
+}
+
+func TestSourceYAML_AnchorsAndDocumentsFromFile(t *testing.T) {
+	p := dp.NewDynamicParams(dp.SrcNameYAML, "testdata/params.yaml")
+
+	name, err := p.GetAsString("name")
+	assert.NoError(t, err)
+	assert.Equal(t, "billing-prod", name)
+
+	port, err := p.GetAsInt("db.port")
+	assert.NoError(t, err)
+	assert.Equal(t, 5432, port)
+
+	retries, err := p.GetAsInt("http.retries")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, retries)
+
+	timeout, err := p.GetAsString("http.timeout")
+	assert.NoError(t, err)
+	assert.Equal(t, "5s", timeout)
+}